@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type archiveChatBody struct {
+	Phone   string `json:"phone"`
+	Archive bool   `json:"archive"`
+}
+
+type archiveChatHandler struct {
+	whatsAppService service.WhatsAppService
+	chatService     service.ChatService
+}
+
+func NewArchiveChatHandler(
+	whatsAppService service.WhatsAppService,
+	chatService service.ChatService,
+) *archiveChatHandler {
+	return &archiveChatHandler{
+		whatsAppService: whatsAppService,
+		chatService:     chatService,
+	}
+}
+
+// Archive WhatsApp Chat
+//
+//	@Summary		Archive WhatsApp Chat
+//	@Description	Archives or unarchives a chat on every device of the instance. Archiving also unpins the chat.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			data		body	archiveChatBody	true	"Phone and whether to archive"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	chatResponse	"Chat"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/chat/archive [post]
+func (h *archiveChatHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body archiveChatBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	err = h.whatsAppService.ArchiveChat(instance, jid, body.Archive)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, err := makeChatResponse(h.chatService, instanceID, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type blockContactBody struct {
+	Phone string `json:"phone"`
+	Block bool   `json:"block"`
+}
+
+type blockContactHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewBlockContactHandler(
+	whatsAppService service.WhatsAppService,
+) *blockContactHandler {
+	return &blockContactHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Block WhatsApp Contact
+//
+//	@Summary		Block WhatsApp Contact
+//	@Description	Blocks or unblocks a phone or JID and returns the updated blocklist.
+//	@Tags			WhatsApp Contact
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	blockContactBody	true	"Phone and whether to block"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	blocklistResponse	"Blocked contacts"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/contact/block [post]
+func (h *blockContactHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body blockContactBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	blocklist, err := h.whatsAppService.UpdateBlocklist(instance, jid, body.Block)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, blocklistResponse{
+		Blocklist: response.NewBlocklistResponse(blocklist),
+	})
+}
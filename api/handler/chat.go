@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+)
+
+type chatResponse struct {
+	Chat response.Chat `json:"chat"`
+}
+
+// makeChatResponse returns the stored state of a chat after changing it.
+func makeChatResponse(
+	chatService service.ChatService,
+	instanceID string,
+	jid whatsapp.JID,
+) (chatResponse, error) {
+	chat, err := chatService.GetChat(instanceID, jid.User)
+	if err != nil {
+		return chatResponse{}, err
+	}
+	if chat == nil {
+		chat = &model.Chat{
+			InstanceID: instanceID,
+			ChatJID:    jid.User,
+			RemoteJID:  jid.String(),
+		}
+	}
+	return chatResponse{Chat: response.NewChatResponse(*chat)}, nil
+}
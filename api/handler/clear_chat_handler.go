@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type clearChatBody struct {
+	Phone string `json:"phone"`
+}
+
+type clearChatHandler struct {
+	whatsAppService service.WhatsAppService
+	chatService     service.ChatService
+}
+
+func NewClearChatHandler(
+	whatsAppService service.WhatsAppService,
+	chatService service.ChatService,
+) *clearChatHandler {
+	return &clearChatHandler{
+		whatsAppService: whatsAppService,
+		chatService:     chatService,
+	}
+}
+
+// Clear WhatsApp Chat
+//
+//	@Summary		Clear WhatsApp Chat
+//	@Description	Deletes every message of a chat on every device of the instance, along with the stored messages and media. The chat itself is kept.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			data		body	clearChatBody	true	"Phone"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	chatResponse	"Chat"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/chat/clear [post]
+func (h *clearChatHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body clearChatBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	err = h.whatsAppService.ClearChat(instance, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, err := makeChatResponse(h.chatService, instanceID, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
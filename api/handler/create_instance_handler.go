@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createInstanceBody struct {
+	// InstanceID has 1 to 64 letters, digits, "-" or "_"
+	InstanceID string `json:"instance_id" binding:"required"`
+}
+
+type createInstanceResponse struct {
+	Instance response.Instance `json:"instance"`
+}
+
+type createInstanceHandler struct {
+	whatsAppService service.WhatsAppService
+	accountService  service.AccountService
+}
+
+func NewCreateInstanceHandler(
+	whatsAppService service.WhatsAppService,
+	accountService service.AccountService,
+) *createInstanceHandler {
+	return &createInstanceHandler{
+		whatsAppService: whatsAppService,
+		accountService:  accountService,
+	}
+}
+
+// Create WhatsApp Instance
+//
+//	@Summary		Create WhatsApp Instance
+//	@Description	Creates a new instance and starts generating QR codes for it.
+//	@Tags			WhatsApp Instances
+//	@Param			data	body	createInstanceBody	true	"Instance"
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	createInstanceResponse	"Instance"
+//	@Failure		400	{object}	response.Error			"Invalid instance ID"
+//	@Failure		409	{object}	response.Error			"Instance already exists"
+//	@Router			/instances [post]
+func (h *createInstanceHandler) Handler(c *gin.Context) {
+	var body createInstanceBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if !helper.IsValidInstanceID(body.InstanceID) {
+		response.ErrorResponse(c, http.StatusBadRequest, service.ErrInvalidInstanceID.Error())
+		return
+	}
+
+	instance, err := h.whatsAppService.CreateInstance(body.InstanceID)
+	if errors.Is(err, service.ErrInstanceExists) {
+		response.ErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	account, err := h.accountService.GetAccountByInstanceID(body.InstanceID)
+	if err != nil || account == nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, "Account not found")
+		return
+	}
+
+	response.Response(c, http.StatusCreated, createInstanceResponse{
+		Instance: response.NewInstanceResponse(
+			*account,
+			h.whatsAppService.IsAuthenticated(instance),
+		),
+	})
+}
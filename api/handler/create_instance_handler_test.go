@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordingWhatsAppService struct {
+	service.WhatsAppService
+	created []string
+}
+
+func (s *recordingWhatsAppService) CreateInstance(instanceID string) (*whatsapp.Instance, error) {
+	s.created = append(s.created, instanceID)
+	return nil, service.ErrInstanceExists
+}
+
+func TestCreateInstanceRejectsInvalidIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ids := []string{
+		"..",
+		"a/../b",
+		"a/b",
+		"a.b",
+		"",
+		strings.Repeat("a", 65),
+	}
+	for _, id := range ids {
+		whatsAppService := &recordingWhatsAppService{}
+		h := NewCreateInstanceHandler(whatsAppService, nil)
+
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(
+			http.MethodPost,
+			"/api/instances",
+			strings.NewReader(`{"instance_id": "`+id+`"}`),
+		)
+		h.Handler(c)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("instance id %q: got status %d, want %d", id, recorder.Code, http.StatusBadRequest)
+		}
+		if len(whatsAppService.created) > 0 {
+			t.Errorf("instance id %q was created", id)
+		}
+	}
+}
+
+func TestCreateInstanceAcceptsValidIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, id := range []string{"a", "store-01", "Store_02", strings.Repeat("a", 64)} {
+		whatsAppService := &recordingWhatsAppService{}
+		h := NewCreateInstanceHandler(whatsAppService, nil)
+
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(
+			http.MethodPost,
+			"/api/instances",
+			strings.NewReader(`{"instance_id": "`+id+`"}`),
+		)
+		h.Handler(c)
+
+		if len(whatsAppService.created) != 1 {
+			t.Errorf("instance id %q was rejected with status %d", id, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"image"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vincent-petithory/dataurl"
+)
+
+const (
+	maxNewsletterNameLength        = 100
+	maxNewsletterDescriptionLength = 2048
+)
+
+type createNewsletterBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Picture is an optional base64 image, cropped to a centered square
+	Picture string `json:"picture"`
+}
+
+type createNewsletterHandler struct {
+	whatsAppService service.WhatsAppService
+	mediaService    service.MediaService
+}
+
+func NewCreateNewsletterHandler(
+	whatsAppService service.WhatsAppService,
+	mediaService service.MediaService,
+) *createNewsletterHandler {
+	return &createNewsletterHandler{
+		whatsAppService: whatsAppService,
+		mediaService:    mediaService,
+	}
+}
+
+// Create WhatsApp Newsletter
+//
+//	@Summary		Create WhatsApp Newsletter
+//	@Description	Creates a channel owned by the instance, accepting the channel terms of WhatsApp on its behalf.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string					true	"Instance ID"
+//	@Param			data		body	createNewsletterBody	true	"Name, description and picture"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	newsletterResponse	"Created newsletter"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Failure		413	{object}	response.Error
+//	@Router			/{instanceId}/newsletters [post]
+func (h *createNewsletterHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Image))
+
+	var body createNewsletterBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		response.ErrorResponse(c, http.StatusBadRequest, "Name is required")
+		return
+	}
+	if utf8.RuneCountInString(body.Name) > maxNewsletterNameLength {
+		response.ErrorResponse(c, http.StatusBadRequest, "Name is too long")
+		return
+	}
+	if utf8.RuneCountInString(body.Description) > maxNewsletterDescriptionLength {
+		response.ErrorResponse(c, http.StatusBadRequest, "Description is too long")
+		return
+	}
+
+	var picture []byte
+	if body.Picture != "" {
+		imageURL, err := dataurl.DecodeString(body.Picture)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		picture, err = helper.MakeProfilePicture(imageURL.Data, nil)
+		if errors.Is(err, image.ErrFormat) {
+			response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	newsletter, err := h.whatsAppService.CreateNewsletter(instance, body.Name, body.Description, picture)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, newsletterResponse{
+		Newsletter: response.NewNewsletterResponse(*newsletter),
+	})
+}
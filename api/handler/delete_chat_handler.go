@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteChatBody struct {
+	Phone string `json:"phone"`
+}
+
+type deleteChatHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewDeleteChatHandler(
+	whatsAppService service.WhatsAppService,
+) *deleteChatHandler {
+	return &deleteChatHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Delete WhatsApp Chat
+//
+//	@Summary		Delete WhatsApp Chat
+//	@Description	Deletes a chat with its messages on every device of the instance, along with the stored messages and media.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			data		body	deleteChatBody	true	"Phone"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Chat deleted"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/chat/delete [post]
+func (h *deleteChatHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body deleteChatBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	err = h.whatsAppService.DeleteChat(instance, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, gin.H{})
+}
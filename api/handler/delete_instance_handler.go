@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteInstanceHandler struct {
+	whatsAppService service.WhatsAppService
+	mediaService    service.MediaService
+}
+
+func NewDeleteInstanceHandler(
+	whatsAppService service.WhatsAppService,
+	mediaService service.MediaService,
+) *deleteInstanceHandler {
+	return &deleteInstanceHandler{
+		whatsAppService: whatsAppService,
+		mediaService:    mediaService,
+	}
+}
+
+// Delete WhatsApp Instance
+//
+//	@Summary		Delete WhatsApp Instance
+//	@Description	Logs out the instance and wipes its device store, messages and media.
+//	@Tags			WhatsApp Instances
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Instance deleted"
+//	@Failure		404	{object}	response.Error			"Instance not found"
+//	@Router			/instances/{instanceId} [delete]
+func (h *deleteInstanceHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	err := h.whatsAppService.DeleteInstance(instanceID)
+	if errors.Is(err, service.ErrInstanceNotFound) {
+		response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	err = h.mediaService.DeleteInstanceMedia(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, gin.H{})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type downloadMediaBody struct {
+	MessageID string `json:"message_id"`
+}
+
+type downloadMediaResponse struct {
+	Message response.Message `json:"message"`
+}
+
+type downloadMediaHandler struct {
+	whatsAppService service.WhatsAppService
+	messageService  service.MessageService
+}
+
+func NewDownloadMediaHandler(
+	whatsAppService service.WhatsAppService,
+	messageService service.MessageService,
+) *downloadMediaHandler {
+	return &downloadMediaHandler{
+		whatsAppService: whatsAppService,
+		messageService:  messageService,
+	}
+}
+
+// Download Message Media
+//
+//	@Summary		Download Message Media
+//	@Description	Downloads the media of a stored message right away, instead of waiting for the media queue.
+//	@Tags			WhatsApp Media
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	downloadMediaBody	true	"Message ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	downloadMediaResponse	"Message with its media"
+//	@Success		202	{object}	downloadMediaResponse	"Media expired on the CDN, a re-upload was requested from the phone"
+//	@Router			/{instanceId}/media/download [post]
+func (h *downloadMediaHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var body downloadMediaBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	message, err := h.messageService.GetMessageByMessageID(instanceID, body.MessageID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if message == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Message not found")
+		return
+	}
+
+	if len(message.RawMessage) == 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Message has no downloadable media")
+		return
+	}
+
+	err = h.whatsAppService.DownloadMedia(instance, message)
+	if errors.Is(err, service.ErrMediaRetryRequested) {
+		response.Response(c, http.StatusAccepted, downloadMediaResponse{
+			Message: response.NewMessageResponse(*message),
+		})
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, downloadMediaResponse{
+		Message: response.NewMessageResponse(*message),
+	})
+}
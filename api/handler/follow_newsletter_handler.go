@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type followNewsletterBody struct {
+	ID     string `json:"id"`
+	Invite string `json:"invite"`
+	Follow bool   `json:"follow"`
+}
+
+type followNewsletterHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewFollowNewsletterHandler(
+	whatsAppService service.WhatsAppService,
+) *followNewsletterHandler {
+	return &followNewsletterHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Follow WhatsApp Newsletter
+//
+//	@Summary		Follow WhatsApp Newsletter
+//	@Description	Follows or unfollows a channel found by its ID or by its invite code or link.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string					true	"Instance ID"
+//	@Param			data		body	followNewsletterBody	true	"Newsletter and whether to follow it"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	newsletterResponse	"Newsletter"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/newsletter/follow [post]
+func (h *followNewsletterHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body followNewsletterBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	newsletter, err := findNewsletter(h.whatsAppService, instance, body.ID, body.Invite)
+	if errors.Is(err, errInvalidNewsletter) {
+		response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if newsletter == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Newsletter not found")
+		return
+	}
+
+	err = h.whatsAppService.FollowNewsletter(instance, newsletter.JID, body.Follow)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// the role changes with the subscription
+	updated, err := h.whatsAppService.GetNewsletter(instance, newsletter.JID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if updated != nil {
+		newsletter = updated
+	}
+
+	response.Response(c, http.StatusOK, newsletterResponse{
+		Newsletter: response.NewNewsletterResponse(*newsletter),
+	})
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type blocklistResponse struct {
+	Blocklist []response.BlockedContact `json:"blocklist"`
+}
+
+type getBlocklistHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetBlocklistHandler(
+	whatsAppService service.WhatsAppService,
+) *getBlocklistHandler {
+	return &getBlocklistHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get WhatsApp Blocklist
+//
+//	@Summary		Get WhatsApp Blocklist
+//	@Description	Lists the contacts blocked by the instance.
+//	@Tags			WhatsApp Contact
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	blocklistResponse	"Blocked contacts"
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/blocklist [get]
+func (h *getBlocklistHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	blocklist, err := h.whatsAppService.GetBlocklist(instance)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, blocklistResponse{
+		Blocklist: response.NewBlocklistResponse(blocklist),
+	})
+}
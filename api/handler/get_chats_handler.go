@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/repository"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultChatsLimit = 50
+	maxChatsLimit     = 500
+)
+
+type getChatsQuery struct {
+	Sort     string `form:"sort"`
+	Archived *bool  `form:"archived"`
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+}
+
+type getChatsResponse struct {
+	Chats []response.Chat `json:"chats"`
+	Total int64           `json:"total"`
+}
+
+type getChatsHandler struct {
+	accountService service.AccountService
+	chatService    service.ChatService
+}
+
+func NewGetChatsHandler(
+	accountService service.AccountService,
+	chatService service.ChatService,
+) *getChatsHandler {
+	return &getChatsHandler{
+		accountService: accountService,
+		chatService:    chatService,
+	}
+}
+
+// Get WhatsApp Chats
+//
+//	@Summary		Get WhatsApp Chats
+//	@Description	Returns the chats of the specified instance with their last message time, unread count and name.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			sort		query	string	false	"last_message (default), name or unread"
+//	@Param			archived	query	bool	false	"Only archived or only unarchived chats"
+//	@Param			limit		query	int		false	"Maximum chats, 50 by default"
+//	@Param			offset		query	int		false	"Chats to skip"
+//	@Produce		json
+//	@Success		200	{object}	getChatsResponse	"List of chats"
+//	@Failure		400	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/chats [get]
+func (h *getChatsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var query getChatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	switch query.Sort {
+	case "", repository.ChatSortLastMessage, repository.ChatSortName, repository.ChatSortUnread:
+	default:
+		response.ErrorResponse(c, http.StatusBadRequest, "Sort must be last_message, name or unread")
+		return
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultChatsLimit
+	}
+	if query.Limit > maxChatsLimit {
+		query.Limit = maxChatsLimit
+	}
+
+	chats, total, err := h.chatService.GetChats(repository.ChatQuery{
+		InstanceID: instanceID,
+		Archived:   query.Archived,
+		Sort:       query.Sort,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, getChatsResponse{
+		Chats: response.NewChatsResponse(chats),
+		Total: total,
+	})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/repository"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultContactsLimit = 100
+	maxContactsLimit     = 1000
+)
+
+type getContactsQuery struct {
+	Q      string `form:"q"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type getContactsResponse struct {
+	Contacts []response.Contact `json:"contacts"`
+	Total    int64              `json:"total"`
+}
+
+type getContactsHandler struct {
+	accountService service.AccountService
+	contactService service.ContactService
+}
+
+func NewGetContactsHandler(
+	accountService service.AccountService,
+	contactService service.ContactService,
+) *getContactsHandler {
+	return &getContactsHandler{
+		accountService: accountService,
+		contactService: contactService,
+	}
+}
+
+// Get WhatsApp Contacts
+//
+//	@Summary		Get WhatsApp Contacts
+//	@Description	Returns the stored contacts of the specified instance, saved contacts first by their address book name.
+//	@Tags			WhatsApp Contact
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			q			query	string	false	"Text found in any name or the phone"
+//	@Param			limit		query	int		false	"Maximum contacts, 100 by default"
+//	@Param			offset		query	int		false	"Contacts to skip"
+//	@Produce		json
+//	@Success		200	{object}	getContactsResponse	"List of contacts"
+//	@Failure		400	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/contacts [get]
+func (h *getContactsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var query getContactsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultContactsLimit
+	}
+	if query.Limit > maxContactsLimit {
+		query.Limit = maxContactsLimit
+	}
+
+	contacts, total, err := h.contactService.GetContacts(repository.ContactQuery{
+		InstanceID: instanceID,
+		Search:     query.Q,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, getContactsResponse{
+		Contacts: response.NewContactsResponse(contacts),
+		Total:    total,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxContactsInfoPhones is how many phones a single lookup accepts.
+const maxContactsInfoPhones = 100
+
+type getContactsInfoBody struct {
+	Phones           []string `json:"phones"`
+	DownloadPictures bool     `json:"download_pictures"`
+}
+
+type contactInfoResult struct {
+	Phone   string                `json:"phone"`
+	Found   bool                  `json:"found"`
+	Contact *response.ContactInfo `json:"contact,omitempty"`
+}
+
+type getContactsInfoResponse struct {
+	Contacts []contactInfoResult `json:"contacts"`
+}
+
+type getContactsInfoHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetContactsInfoHandler(
+	whatsAppService service.WhatsAppService,
+) *getContactsInfoHandler {
+	return &getContactsInfoHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get Contacts Information
+//
+//	@Summary		Get Contacts Information
+//	@Description	Looks up the name, status and profile picture ID of up to 100 phones in one request.
+//	@Description	With download_pictures, profile pictures are saved and returned inline. A picture is only downloaded again after it changed.
+//	@Tags			WhatsApp Contact
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	getContactsInfoBody	true	"Phone list"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	getContactsInfoResponse	"Contacts Information"
+//	@Failure		400	{object}	response.Error
+//	@Router			/{instanceId}/contacts/info [post]
+func (h *getContactsInfoHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body getContactsInfoBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if len(body.Phones) == 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Phones are required")
+		return
+	}
+	if len(body.Phones) > maxContactsInfoPhones {
+		response.ErrorResponse(
+			c,
+			http.StatusBadRequest,
+			fmt.Sprintf("At most %d phones are allowed per request", maxContactsInfoPhones),
+		)
+		return
+	}
+
+	jids := []whatsapp.JID{}
+	for _, phone := range body.Phones {
+		jid, ok := helper.MakeJID(phone)
+		if !ok {
+			response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone "+phone)
+			return
+		}
+		jids = append(jids, jid)
+	}
+
+	contacts, err := h.whatsAppService.GetContactsInfo(instance, jids, body.DownloadPictures)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	found := map[string]response.ContactInfo{}
+	for _, contact := range *contacts {
+		found[contact.ContactJID] = response.NewContactInfoResponse(contact)
+	}
+
+	results := []contactInfoResult{}
+	for i, jid := range jids {
+		result := contactInfoResult{Phone: body.Phones[i]}
+		if contact, ok := found[jid.User]; ok {
+			result.Found = true
+			result.Contact = &contact
+		}
+		results = append(results, result)
+	}
+
+	response.Response(c, http.StatusOK, getContactsInfoResponse{
+		Contacts: results,
+	})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getMediaRetentionResponse struct {
+	Policies []response.MediaRetentionPolicy `json:"policies"`
+}
+
+type getMediaRetentionHandler struct {
+	accountService service.AccountService
+	mediaService   service.MediaService
+}
+
+func NewGetMediaRetentionHandler(
+	accountService service.AccountService,
+	mediaService service.MediaService,
+) *getMediaRetentionHandler {
+	return &getMediaRetentionHandler{
+		accountService: accountService,
+		mediaService:   mediaService,
+	}
+}
+
+// Get Media Retention Policies
+//
+//	@Summary		Get Media Retention Policies
+//	@Description	Returns the media retention policies configured for the specified instance.
+//	@Tags			WhatsApp Media
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	getMediaRetentionResponse	"Media retention policies"
+//	@Router			/{instanceId}/media/retention [get]
+func (h *getMediaRetentionHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	policies, err := h.mediaService.GetRetentionPolicies(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, getMediaRetentionResponse{
+		Policies: response.NewMediaRetentionPoliciesResponse(policies),
+	})
+}
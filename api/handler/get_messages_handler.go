@@ -1,19 +1,49 @@
 package handler
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
 	"zapmeow/api/response"
 	"zapmeow/api/service"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	maxMessagesLimit = 1000
+	// cursorPrefix marks the cursors returned with a page, which message IDs
+	// never start with
+	cursorPrefix = "c."
+)
+
 type getMessagesBody struct {
 	Phone string `json:"phone"`
+	// Before and After take a cursor of a previous page of the same chat, a
+	// message ID or an RFC 3339 timestamp
+	Before       string     `json:"before"`
+	After        string     `json:"after"`
+	Limit        int        `json:"limit"`
+	Sender       string     `json:"sender"`
+	FromMe       *bool      `json:"from_me"`
+	MediaType    string     `json:"media_type"`
+	Since        *time.Time `json:"since"`
+	Until        *time.Time `json:"until"`
+	MetadataOnly bool       `json:"metadata_only"`
 }
 
 type getMessagesResponse struct {
 	Messages []response.Message `json:"messages"`
+	HasMore  bool               `json:"has_more"`
+	// cursors for the older and newer pages around this one
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
 }
 
 type getMessagesHandler struct {
@@ -34,13 +64,17 @@ func NewGetMessagesHandler(
 // Get WhatsApp Chat Messages
 //
 //	@Summary		Get WhatsApp Chat Messages
-//	@Description	Returns chat messages from the specified WhatsApp instance.
+//	@Description	Returns chat messages from the specified WhatsApp instance, newest first.
+//	@Description	Pages with a limit and a before or after cursor, which is a cursor returned for the same chat, a message ID or an RFC 3339 timestamp.
+//	@Description	A cursor of another chat is rejected.
+//	@Description	Without a limit every matching message is returned.
 //	@Tags			WhatsApp Chat
 //	@Param			instanceId	path	string			true	"Instance ID"
-//	@Param			data		body	getMessagesBody	true	"Phone"
+//	@Param			data		body	getMessagesBody	true	"Phone, cursor and filters"
 //	@Accept			json
 //	@Produce		json
 //	@Success		200	{object}	getMessagesResponse	"List of chat messages"
+//	@Failure		400	{object}	response.Error
 //	@Router			/{instanceId}/chat/messages [post]
 func (h *getMessagesHandler) Handler(c *gin.Context) {
 	instanceID := c.Param("instanceId")
@@ -61,16 +95,147 @@ func (h *getMessagesHandler) Handler(c *gin.Context) {
 		return
 	}
 
-	messages, err := h.messageService.GetChatMessages(
-		instanceID,
-		body.Phone,
-	)
+	if body.Limit < 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Limit must not be negative")
+		return
+	}
+	if body.Limit > maxMessagesLimit {
+		body.Limit = maxMessagesLimit
+	}
+
+	query := repository.MessageQuery{
+		InstanceID: instanceID,
+		ChatJID:    body.Phone,
+		SenderJID:  body.Sender,
+		FromMe:     body.FromMe,
+		MediaType:  body.MediaType,
+		Since:      body.Since,
+		Until:      body.Until,
+	}
+
+	if body.Before != "" {
+		query.Before, err = h.makeCursor(instanceID, body.Phone, body.Before)
+		if err != nil {
+			response.ErrorResponse(c, cursorErrorStatus(err), err.Error())
+			return
+		}
+		if query.Before == nil {
+			response.ErrorResponse(c, http.StatusBadRequest, "Cursor message not found")
+			return
+		}
+	}
+	if body.After != "" {
+		query.After, err = h.makeCursor(instanceID, body.Phone, body.After)
+		if err != nil {
+			response.ErrorResponse(c, cursorErrorStatus(err), err.Error())
+			return
+		}
+		if query.After == nil {
+			response.ErrorResponse(c, http.StatusBadRequest, "Cursor message not found")
+			return
+		}
+	}
+
+	// one extra row tells whether another page follows
+	if body.Limit > 0 {
+		query.Limit = body.Limit + 1
+	}
+
+	messages, err := h.messageService.QueryChatMessages(query)
 	if err != nil {
-		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		response.ErrorResponse(c, cursorErrorStatus(err), err.Error())
 		return
 	}
 
-	response.Response(c, http.StatusOK, getMessagesResponse{
-		Messages: response.NewMessagesResponse(messages),
-	})
+	page := *messages
+	hasMore := body.Limit > 0 && len(page) > body.Limit
+	if hasMore {
+		// the extra row lies beyond the page in the paging direction
+		if query.After != nil && query.Before == nil {
+			page = page[1:]
+		} else {
+			page = page[:body.Limit]
+		}
+	}
+
+	resp := getMessagesResponse{HasMore: hasMore}
+	if body.MetadataOnly {
+		resp.Messages = response.NewMessagesMetadataResponse(&page)
+	} else {
+		resp.Messages = response.NewMessagesResponse(&page)
+	}
+	if len(page) > 0 {
+		resp.After = encodeCursor(page[0])
+		resp.Before = encodeCursor(page[len(page)-1])
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
+
+// makeCursor returns nil when the message ID is not stored in the chat.
+func (h *getMessagesHandler) makeCursor(
+	instanceID string,
+	chatJID string,
+	cursor string,
+) (*repository.MessageCursor, error) {
+	if timestamp, err := time.Parse(time.RFC3339, cursor); err == nil {
+		return &repository.MessageCursor{Timestamp: timestamp}, nil
+	}
+	if strings.HasPrefix(cursor, cursorPrefix) {
+		return decodeCursor(cursor)
+	}
+
+	message, err := h.messageService.GetMessageByMessageID(instanceID, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil || message.ChatJID != chatJID {
+		return nil, nil
+	}
+	return &repository.MessageCursor{
+		ChatJID:   message.ChatJID,
+		Timestamp: message.Timestamp,
+		ID:        message.ID,
+	}, nil
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor points past the message, binding the cursor to its chat.
+func encodeCursor(message model.Message) string {
+	value := fmt.Sprintf("%s|%d|%d", message.ChatJID, message.Timestamp.UnixNano(), message.ID)
+	return cursorPrefix + base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeCursor(cursor string) (*repository.MessageCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(cursor, cursorPrefix))
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	parts := strings.Split(string(value), "|")
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, errInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 0)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &repository.MessageCursor{
+		ChatJID:   parts[0],
+		Timestamp: time.Unix(0, nanos),
+		ID:        uint(id),
+	}, nil
+}
+
+func cursorErrorStatus(err error) int {
+	if errors.Is(err, errInvalidCursor) || errors.Is(err, repository.ErrCursorChatMismatch) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCursorKeepsItsChat(t *testing.T) {
+	message := model.Message{
+		ChatJID:   "5511999999999@s.whatsapp.net",
+		Timestamp: time.Unix(1700000000, 123),
+	}
+	message.ID = 42
+
+	cursor, err := decodeCursor(encodeCursor(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor.ChatJID != message.ChatJID || cursor.ID != 42 || !cursor.Timestamp.Equal(message.Timestamp) {
+		t.Fatalf("cursor = %+v", cursor)
+	}
+}
+
+func TestInvalidCursorIsABadRequest(t *testing.T) {
+	for _, value := range []string{cursorPrefix + "!!", cursorPrefix + "YQ"} {
+		_, err := decodeCursor(value)
+		if cursorErrorStatus(err) != http.StatusBadRequest {
+			t.Errorf("decodeCursor(%q) = %v", value, err)
+		}
+	}
+	if cursorErrorStatus(repository.ErrCursorChatMismatch) != http.StatusBadRequest {
+		t.Error("a cursor of another chat is not a bad request")
+	}
+	if cursorErrorStatus(errors.New("db down")) != http.StatusInternalServerError {
+		t.Error("a storage error is a bad request")
+	}
+}
+
+type authenticatedWhatsAppService struct {
+	service.WhatsAppService
+}
+
+func (s *authenticatedWhatsAppService) GetInstance(instanceID string) (*whatsapp.Instance, error) {
+	return &whatsapp.Instance{ID: instanceID}, nil
+}
+
+func (s *authenticatedWhatsAppService) IsAuthenticated(instance *whatsapp.Instance) bool {
+	return true
+}
+
+type emptyChatMessageService struct {
+	service.MessageService
+}
+
+func (s *emptyChatMessageService) QueryChatMessages(query repository.MessageQuery) (*[]model.Message, error) {
+	return &[]model.Message{}, nil
+}
+
+func TestEmptyPageHasNoMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewGetMessagesHandler(&authenticatedWhatsAppService{}, &emptyChatMessageService{})
+
+	chatJID := "5511999999999@s.whatsapp.net"
+	before := encodeCursor(model.Message{ChatJID: chatJID, Timestamp: time.Now()})
+	for _, metadataOnly := range []bool{false, true} {
+		body, _ := json.Marshal(map[string]interface{}{
+			"phone":         chatJID,
+			"before":        before,
+			"limit":         20,
+			"metadata_only": metadataOnly,
+		})
+
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Params = gin.Params{{Key: "instanceId", Value: "a"}}
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/a/chat/messages", bytes.NewReader(body))
+		h.Handler(c)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+		}
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if string(resp["messages"]) != "[]" {
+			t.Errorf("metadata_only %v: messages = %s, want []", metadataOnly, resp["messages"])
+		}
+	}
+}
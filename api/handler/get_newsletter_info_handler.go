@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getNewsletterInfoQuery struct {
+	ID     string `form:"id"`
+	Invite string `form:"invite"`
+}
+
+type getNewsletterInfoHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetNewsletterInfoHandler(
+	whatsAppService service.WhatsAppService,
+) *getNewsletterInfoHandler {
+	return &getNewsletterInfoHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get WhatsApp Newsletter Info
+//
+//	@Summary		Get WhatsApp Newsletter Info
+//	@Description	Returns a channel found by its ID or by its invite code or link.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			id			query	string	false	"Newsletter ID"
+//	@Param			invite		query	string	false	"Invite code or link, used without an ID"
+//	@Produce		json
+//	@Success		200	{object}	newsletterResponse	"Newsletter"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/newsletter/info [get]
+func (h *getNewsletterInfoHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var query getNewsletterInfoQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	newsletter, err := findNewsletter(h.whatsAppService, instance, query.ID, query.Invite)
+	if errors.Is(err, errInvalidNewsletter) {
+		response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if newsletter == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Newsletter not found")
+		return
+	}
+
+	response.Response(c, http.StatusOK, newsletterResponse{
+		Newsletter: response.NewNewsletterResponse(*newsletter),
+	})
+}
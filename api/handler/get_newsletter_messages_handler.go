@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultNewsletterMessagesLimit = 50
+	maxNewsletterMessagesLimit     = 100
+)
+
+type getNewsletterMessagesQuery struct {
+	ID string `form:"id"`
+	// Before is the server ID of a post, as given in the previous page
+	Before int `form:"before"`
+	Limit  int `form:"limit"`
+}
+
+type getNewsletterMessagesResponse struct {
+	Messages []response.NewsletterMessage `json:"messages"`
+	// cursor for the older page
+	Before int `json:"before,omitempty"`
+}
+
+type getNewsletterMessagesHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetNewsletterMessagesHandler(
+	whatsAppService service.WhatsAppService,
+) *getNewsletterMessagesHandler {
+	return &getNewsletterMessagesHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get WhatsApp Newsletter Messages
+//
+//	@Summary		Get WhatsApp Newsletter Messages
+//	@Description	Fetches the posts of a channel from WhatsApp, paging back with the before cursor.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			id			query	string	true	"Newsletter ID"
+//	@Param			before		query	int		false	"Server ID of the post to page back from"
+//	@Param			limit		query	int		false	"Maximum posts, 50 by default"
+//	@Produce		json
+//	@Success		200	{object}	getNewsletterMessagesResponse	"List of posts"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/newsletter/messages [get]
+func (h *getNewsletterMessagesHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var query getNewsletterMessagesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := makeNewsletterJID(query.ID)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, errInvalidNewsletter.Error())
+		return
+	}
+
+	if query.Before < 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Before must not be negative")
+		return
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultNewsletterMessagesLimit
+	}
+	if query.Limit > maxNewsletterMessagesLimit {
+		query.Limit = maxNewsletterMessagesLimit
+	}
+
+	messages, err := h.whatsAppService.GetNewsletterMessages(instance, jid, query.Limit, query.Before)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := getNewsletterMessagesResponse{
+		Messages: response.NewNewsletterMessagesResponse(messages),
+	}
+	for _, message := range messages {
+		if resp.Before == 0 || message.ServerID < resp.Before {
+			resp.Before = message.ServerID
+		}
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getNewslettersResponse struct {
+	Newsletters []response.Newsletter `json:"newsletters"`
+}
+
+type getNewslettersHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetNewslettersHandler(
+	whatsAppService service.WhatsAppService,
+) *getNewslettersHandler {
+	return &getNewslettersHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get WhatsApp Newsletters
+//
+//	@Summary		Get WhatsApp Newsletters
+//	@Description	Lists the channels the instance follows or owns.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	getNewslettersResponse	"List of newsletters"
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/newsletters [get]
+func (h *getNewslettersHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	newsletters, err := h.whatsAppService.GetSubscribedNewsletters(instance)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, getNewslettersResponse{
+		Newsletters: response.NewNewslettersResponse(newsletters),
+	})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type privacySettingsResponse struct {
+	Privacy whatsapp.PrivacySettings `json:"privacy"`
+}
+
+type getPrivacySettingsHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetPrivacySettingsHandler(
+	whatsAppService service.WhatsAppService,
+) *getPrivacySettingsHandler {
+	return &getPrivacySettingsHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get Privacy Settings
+//
+//	@Summary		Get Privacy Settings
+//	@Description	Returns who can see the last seen, online status, profile photo and about, whether read receipts are sent, and who can add the instance to groups or call it.
+//	@Tags			WhatsApp Profile
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	privacySettingsResponse	"Privacy settings"
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/profile/privacy [get]
+func (h *getPrivacySettingsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	settings, err := h.whatsAppService.GetPrivacySettings(instance)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, privacySettingsResponse{
+		Privacy: settings,
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"zapmeow/api/helper"
 	"zapmeow/api/response"
 	"zapmeow/api/service"
 	"zapmeow/pkg/zapmeow"
@@ -9,8 +10,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const qrCodeImageSize = 256
+
 type getQrCodeResponse struct {
 	QrCode string `json:"qrcode"`
+	Image  string `json:"image,omitempty"`
 }
 
 type getQrCodeHandler struct {
@@ -37,11 +41,16 @@ func NewGetQrCodeHandler(
 // Get QR Code for WhatsApp Login
 //
 //	@Summary		Get WhatsApp QR Code
-//	@Description	Returns a QR code to initiate WhatsApp login.
+//	@Description	Returns a QR code to initiate WhatsApp login, optionally rendered as an image.
 //	@Tags			WhatsApp Login
 //	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			format		query	string	false	"Image format"	Enums(png, svg, base64)
 //	@Produce		json
+//	@Produce		png
+//	@Produce		image/svg+xml
 //	@Success		200	{object}	getQrCodeResponse	"QR Code"
+//	@Failure		400	{object}	response.Error	"Invalid format"
+//	@Failure		404	{object}	response.Error	"No QR code available"
 //	@Router			/{instanceId}/qrcode [get]
 func (h *getQrCodeHandler) Handler(c *gin.Context) {
 	instanceID := c.Param("instanceId")
@@ -64,7 +73,45 @@ func (h *getQrCodeHandler) Handler(c *gin.Context) {
 		return
 	}
 
-	response.Response(c, http.StatusOK, getQrCodeResponse{
-		QrCode: account.QrCode,
-	})
+	format := c.Query("format")
+	if format == "" {
+		response.Response(c, http.StatusOK, getQrCodeResponse{
+			QrCode: account.QrCode,
+		})
+		return
+	}
+
+	if account.QrCode == "" {
+		response.ErrorResponse(c, http.StatusNotFound, "No QR code available")
+		return
+	}
+
+	switch format {
+	case "png":
+		png, err := helper.MakeQrCodePNG(account.QrCode, qrCodeImageSize)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "image/png", png)
+	case "svg":
+		svg, err := helper.MakeQrCodeSVG(account.QrCode)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "image/svg+xml", []byte(svg))
+	case "base64":
+		image, err := helper.MakeQrCodeDataURI(account.QrCode, qrCodeImageSize)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Response(c, http.StatusOK, getQrCodeResponse{
+			QrCode: account.QrCode,
+			Image:  image,
+		})
+	default:
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid format")
+	}
 }
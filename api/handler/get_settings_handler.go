@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type effectiveSettings struct {
+	WebhookURL        string `json:"webhook_url"`
+	HistorySync       bool   `json:"history_sync"`
+	MaxMessageSync    int    `json:"max_message_sync"`
+	AutoRead          bool   `json:"auto_read"`
+	RejectCalls       bool   `json:"reject_calls"`
+	RejectCallMessage string `json:"reject_call_message"`
+}
+
+type getSettingsResponse struct {
+	Settings  response.InstanceSettings `json:"settings"`
+	Effective effectiveSettings         `json:"effective"`
+}
+
+type getSettingsHandler struct {
+	accountService  service.AccountService
+	settingsService service.SettingsService
+}
+
+func NewGetSettingsHandler(
+	accountService service.AccountService,
+	settingsService service.SettingsService,
+) *getSettingsHandler {
+	return &getSettingsHandler{
+		accountService:  accountService,
+		settingsService: settingsService,
+	}
+}
+
+// Get Instance Settings
+//
+//	@Summary		Get Instance Settings
+//	@Description	Returns the metadata and setting overrides of the specified instance, along with the effective settings after falling back to the global config.
+//	@Tags			WhatsApp Instances
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	getSettingsResponse	"Instance settings"
+//	@Router			/{instanceId}/settings [get]
+func (h *getSettingsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, makeSettingsResponse(*account, settings))
+}
+
+func makeSettingsResponse(account model.Account, settings service.Settings) getSettingsResponse {
+	return getSettingsResponse{
+		Settings: response.NewInstanceSettingsResponse(account),
+		Effective: effectiveSettings{
+			WebhookURL:        settings.WebhookURL,
+			HistorySync:       settings.HistorySync,
+			MaxMessageSync:    settings.MaxMessageSync,
+			AutoRead:          settings.AutoRead,
+			RejectCalls:       settings.RejectCalls,
+			RejectCallMessage: settings.RejectCallMessage,
+		},
+	}
+}
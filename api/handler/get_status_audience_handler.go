@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type statusAudienceResponse struct {
+	// Type is "contacts", "blacklist" (all contacts except the list) or
+	// "whitelist" (only the list)
+	Type string   `json:"type"`
+	List []string `json:"list"`
+}
+
+type getStatusAudienceHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewGetStatusAudienceHandler(
+	whatsAppService service.WhatsAppService,
+) *getStatusAudienceHandler {
+	return &getStatusAudienceHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Get WhatsApp Status Audience
+//
+//	@Summary		Get WhatsApp Status Audience
+//	@Description	Returns who receives the status updates posted by the instance.
+//	@Description	The audience is read-only: it follows the status privacy of the account, which can only be changed on the phone.
+//	@Tags			WhatsApp Status
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	statusAudienceResponse	"Status audience"
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/status/audience [get]
+func (h *getStatusAudienceHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	audience, err := h.whatsAppService.GetStatusAudience(instance)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, makeStatusAudienceResponse(audience))
+}
+
+func makeStatusAudienceResponse(audience whatsapp.StatusAudience) statusAudienceResponse {
+	data := statusAudienceResponse{Type: audience.Type, List: []string{}}
+	for _, jid := range audience.List {
+		data.List = append(data.List, jid.User)
+	}
+	return data
+}
@@ -10,7 +10,9 @@ import (
 )
 
 type getStatusResponse struct {
-	Status string `json:"status"`
+	Status            string `json:"status"`
+	DisconnectReason  string `json:"disconnect_reason,omitempty"`
+	ReconnectAttempts int    `json:"reconnect_attempts"`
 }
 
 type getStatusHandler struct {
@@ -72,6 +74,8 @@ func (h *getStatusHandler) Handler(c *gin.Context) {
 	}
 
 	response.Response(c, http.StatusOK, getStatusResponse{
-		Status: status,
+		Status:            status,
+		DisconnectReason:  account.DisconnectReason,
+		ReconnectAttempts: account.ReconnectAttempts,
 	})
 }
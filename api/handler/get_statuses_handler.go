@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"zapmeow/api/repository"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStatusesLimit = 100
+	maxStatusesLimit     = 1000
+)
+
+type getStatusesQuery struct {
+	Sender string     `form:"sender"`
+	FromMe *bool      `form:"from_me"`
+	Since  *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit  int        `form:"limit"`
+	Offset int        `form:"offset"`
+}
+
+type getStatusesResponse struct {
+	Statuses []response.Status `json:"statuses"`
+	Total    int64             `json:"total"`
+}
+
+type getStatusesHandler struct {
+	accountService service.AccountService
+	statusService  service.StatusService
+}
+
+func NewGetStatusesHandler(
+	accountService service.AccountService,
+	statusService service.StatusService,
+) *getStatusesHandler {
+	return &getStatusesHandler{
+		accountService: accountService,
+		statusService:  statusService,
+	}
+}
+
+// Get WhatsApp Statuses
+//
+//	@Summary		Get WhatsApp Statuses
+//	@Description	Returns the stored status updates of the specified instance, newest first.
+//	@Description	They hold both the updates of contacts and the ones the instance posted.
+//	@Tags			WhatsApp Status
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			sender		query	string	false	"Phone of the contact who posted"
+//	@Param			from_me		query	bool	false	"Only the updates posted by the instance, or only the others"
+//	@Param			since		query	string	false	"RFC 3339 timestamp of the oldest update"
+//	@Param			limit		query	int		false	"Maximum updates, 100 by default"
+//	@Param			offset		query	int		false	"Updates to skip"
+//	@Produce		json
+//	@Success		200	{object}	getStatusesResponse	"List of status updates"
+//	@Failure		400	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/statuses [get]
+func (h *getStatusesHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var query getStatusesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultStatusesLimit
+	}
+	if query.Limit > maxStatusesLimit {
+		query.Limit = maxStatusesLimit
+	}
+
+	statuses, total, err := h.statusService.GetStatuses(repository.StatusQuery{
+		InstanceID: instanceID,
+		SenderJID:  query.Sender,
+		FromMe:     query.FromMe,
+		Since:      query.Since,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, getStatusesResponse{
+		Statuses: response.NewStatusesResponse(statuses),
+		Total:    total,
+	})
+}
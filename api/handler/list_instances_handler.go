@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/zapmeow"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listInstancesResponse struct {
+	Instances []response.Instance `json:"instances"`
+}
+
+type listInstancesHandler struct {
+	app             *zapmeow.ZapMeow
+	whatsAppService service.WhatsAppService
+	accountService  service.AccountService
+	clusterService  service.ClusterService
+}
+
+func NewListInstancesHandler(
+	app *zapmeow.ZapMeow,
+	whatsAppService service.WhatsAppService,
+	accountService service.AccountService,
+	clusterService service.ClusterService,
+) *listInstancesHandler {
+	return &listInstancesHandler{
+		app:             app,
+		whatsAppService: whatsAppService,
+		accountService:  accountService,
+		clusterService:  clusterService,
+	}
+}
+
+// List WhatsApp Instances
+//
+//	@Summary		List WhatsApp Instances
+//	@Description	Returns every instance with its status, phone, creation date and whether it is currently connected. In cluster mode, also the node running it.
+//	@Tags			WhatsApp Instances
+//	@Produce		json
+//	@Success		200	{object}	listInstancesResponse	"Instances"
+//	@Router			/instances [get]
+func (h *listInstancesHandler) Handler(c *gin.Context) {
+	accounts, err := h.accountService.GetAccounts()
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	instances := []response.Instance{}
+	for _, account := range accounts {
+		// only look at loaded instances, listing must not start any
+		instance := h.app.LoadInstance(account.InstanceID)
+		connected := instance != nil && h.whatsAppService.IsAuthenticated(instance)
+		data := response.NewInstanceResponse(account, connected)
+
+		if h.clusterService.Enabled() {
+			node, err := h.clusterService.Owner(account.InstanceID)
+			if err != nil {
+				response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			// instances on other nodes are reported as they last saved it
+			if node != nil {
+				data.Node = node.ID
+				if instance == nil {
+					data.Connected = account.Status == "CONNECTED" && account.DisconnectReason == ""
+				}
+			}
+		}
+
+		instances = append(instances, data)
+	}
+
+	response.Response(c, http.StatusOK, listInstancesResponse{
+		Instances: instances,
+	})
+}
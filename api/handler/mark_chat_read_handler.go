@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type markChatReadBody struct {
+	Phone string `json:"phone"`
+	Read  bool   `json:"read"`
+}
+
+type markChatReadHandler struct {
+	whatsAppService service.WhatsAppService
+	chatService     service.ChatService
+}
+
+func NewMarkChatReadHandler(
+	whatsAppService service.WhatsAppService,
+	chatService service.ChatService,
+) *markChatReadHandler {
+	return &markChatReadHandler{
+		whatsAppService: whatsAppService,
+		chatService:     chatService,
+	}
+}
+
+// Mark WhatsApp Chat Read
+//
+//	@Summary		Mark WhatsApp Chat Read
+//	@Description	Marks a whole chat as read, or as unread, on every device of the instance.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			data		body	markChatReadBody	true	"Phone and whether the chat is read"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	chatResponse	"Chat"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/chat/read [post]
+func (h *markChatReadHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body markChatReadBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	err = h.whatsAppService.MarkChatRead(instance, jid, body.Read)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, err := makeChatResponse(h.chatService, instanceID, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
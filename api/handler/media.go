@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// limitMediaBody stops reading a request whose base64 payload can't fit
+// in maxSize bytes, before the whole body is buffered in memory.
+func limitMediaBody(c *gin.Context, maxSize int64) {
+	if maxSize <= 0 {
+		return
+	}
+	encodedSize := (maxSize+2)/3*4 + 64*1024
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, encodedSize)
+}
+
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+func mediaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrMediaTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrMediaMismatch), errors.Is(err, service.ErrMediaTypeNotAllowed):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
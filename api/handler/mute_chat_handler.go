@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type muteChatBody struct {
+	Phone    string `json:"phone"`
+	Mute     bool   `json:"mute"`
+	Duration int    `json:"duration"`
+}
+
+type muteChatHandler struct {
+	whatsAppService service.WhatsAppService
+	chatService     service.ChatService
+}
+
+func NewMuteChatHandler(
+	whatsAppService service.WhatsAppService,
+	chatService service.ChatService,
+) *muteChatHandler {
+	return &muteChatHandler{
+		whatsAppService: whatsAppService,
+		chatService:     chatService,
+	}
+}
+
+// Mute WhatsApp Chat
+//
+//	@Summary		Mute WhatsApp Chat
+//	@Description	Mutes a chat for a duration in seconds, forever when the duration is zero, or unmutes it.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			data		body	muteChatBody	true	"Phone, whether to mute and the duration in seconds"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	chatResponse	"Chat"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/chat/mute [post]
+func (h *muteChatHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body muteChatBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	if body.Duration < 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Duration must not be negative")
+		return
+	}
+
+	err = h.whatsAppService.MuteChat(instance, jid, body.Mute, time.Duration(body.Duration)*time.Second)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, err := makeChatResponse(h.chatService, instanceID, jid)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, resp)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+const newsletterLinkPrefix = "https://whatsapp.com/channel/"
+
+var errInvalidNewsletter = errors.New("invalid newsletter, give its id or invite")
+
+type newsletterResponse struct {
+	Newsletter response.Newsletter `json:"newsletter"`
+}
+
+type newsletterMessageResponse struct {
+	Message response.NewsletterMessage `json:"message"`
+}
+
+// makeNewsletterJID takes the ID of a channel with or without its
+// "@newsletter" server.
+func makeNewsletterJID(id string) (whatsapp.JID, bool) {
+	user, server, found := strings.Cut(id, "@")
+	if user == "" || (found && server != types.NewsletterServer) {
+		return types.EmptyJID, false
+	}
+	for _, c := range user {
+		if c < '0' || c > '9' {
+			return types.EmptyJID, false
+		}
+	}
+	return types.NewJID(user, types.NewsletterServer), true
+}
+
+// findNewsletter looks a channel up by its ID or, failing that, by its
+// invite code or link. It returns nil when the channel does not exist.
+func findNewsletter(
+	whatsAppService service.WhatsAppService,
+	instance *whatsapp.Instance,
+	id string,
+	invite string,
+) (*whatsapp.Newsletter, error) {
+	if id != "" {
+		jid, ok := makeNewsletterJID(id)
+		if !ok {
+			return nil, errInvalidNewsletter
+		}
+		return whatsAppService.GetNewsletter(instance, jid)
+	}
+
+	code := strings.TrimSpace(strings.TrimPrefix(invite, newsletterLinkPrefix))
+	if code == "" {
+		return nil, errInvalidNewsletter
+	}
+	return whatsAppService.GetNewsletterByInvite(instance, code)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type pairPhoneBody struct {
+	Phone string `json:"phone"`
+}
+
+type pairPhoneResponse struct {
+	Code string `json:"code"`
+}
+
+type pairPhoneHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewPairPhoneHandler(
+	whatsAppService service.WhatsAppService,
+) *pairPhoneHandler {
+	return &pairPhoneHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Pair WhatsApp with a Phone Number
+//
+//	@Summary		Pair WhatsApp with a Phone Number
+//	@Description	Returns an 8-character code to link the instance from the phone, as an alternative to scanning the QR code.
+//	@Tags			WhatsApp Login
+//	@Param			instanceId	path	string			true	"Instance ID"
+//	@Param			data		body	pairPhoneBody	true	"Phone"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	pairPhoneResponse	"Pairing code"
+//	@Router			/{instanceId}/pair [post]
+func (h *pairPhoneHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var body pairPhoneBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := helper.MakeJID(body.Phone)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid phone")
+		return
+	}
+
+	code, err := h.whatsAppService.PairPhone(instance, jid.User)
+	if errors.Is(err, service.ErrAlreadyPaired) {
+		response.ErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, pairPhoneResponse{
+		Code: code,
+	})
+}
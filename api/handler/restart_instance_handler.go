@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type restartInstanceHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewRestartInstanceHandler(
+	whatsAppService service.WhatsAppService,
+) *restartInstanceHandler {
+	return &restartInstanceHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Restart WhatsApp Instance
+//
+//	@Summary		Restart WhatsApp Instance
+//	@Description	Drops the connection of the instance and starts it again from the stored session.
+//	@Tags			WhatsApp Instances
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Instance restarted"
+//	@Failure		404	{object}	response.Error			"Instance not found"
+//	@Router			/instances/{instanceId}/restart [post]
+func (h *restartInstanceHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	_, err := h.whatsAppService.RestartInstance(instanceID)
+	if errors.Is(err, service.ErrInstanceNotFound) {
+		response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, gin.H{})
+}
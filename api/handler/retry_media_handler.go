@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type retryMediaBody struct {
+	MessageID string `json:"message_id"`
+}
+
+type retryMediaResponse struct {
+	Message response.Message `json:"message"`
+}
+
+type retryMediaHandler struct {
+	whatsAppService service.WhatsAppService
+	messageService  service.MessageService
+}
+
+func NewRetryMediaHandler(
+	whatsAppService service.WhatsAppService,
+	messageService service.MessageService,
+) *retryMediaHandler {
+	return &retryMediaHandler{
+		whatsAppService: whatsAppService,
+		messageService:  messageService,
+	}
+}
+
+// Request Media Re-upload
+//
+//	@Summary		Request Media Re-upload
+//	@Description	Asks the phone to re-upload the media of a stored message that is no longer on the WhatsApp CDN. The media webhook is sent once the download completes.
+//	@Tags			WhatsApp Media
+//	@Param			instanceId	path	string			true	"Instance ID"
+//	@Param			data		body	retryMediaBody	true	"Message ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		202	{object}	retryMediaResponse	"Re-upload requested"
+//	@Router			/{instanceId}/media/retry [post]
+func (h *retryMediaHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body retryMediaBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	message, err := h.messageService.GetMessageByMessageID(instanceID, body.MessageID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if message == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Message not found")
+		return
+	}
+
+	if len(message.RawMessage) == 0 || message.RemoteJID == "" {
+		response.ErrorResponse(c, http.StatusBadRequest, "Message has no downloadable media")
+		return
+	}
+
+	err = h.whatsAppService.RetryMedia(instance, message)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusAccepted, retryMediaResponse{
+		Message: response.NewMessageResponse(*message),
+	})
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"zapmeow/api/repository"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type searchMessagesQuery struct {
+	Q      string     `form:"q"`
+	Chat   string     `form:"chat"`
+	Sender string     `form:"sender"`
+	Since  *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until  *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit  int        `form:"limit"`
+	Offset int        `form:"offset"`
+}
+
+type searchMessagesResponse struct {
+	Matches []response.MessageMatch `json:"matches"`
+}
+
+type searchMessagesHandler struct {
+	accountService service.AccountService
+	messageService service.MessageService
+}
+
+func NewSearchMessagesHandler(
+	accountService service.AccountService,
+	messageService service.MessageService,
+) *searchMessagesHandler {
+	return &searchMessagesHandler{
+		accountService: accountService,
+		messageService: messageService,
+	}
+}
+
+// Search WhatsApp Messages
+//
+//	@Summary		Search WhatsApp Messages
+//	@Description	Finds messages whose text or caption contains every word of the query, best matches first, with the matched words wrapped in <mark> tags.
+//	@Tags			WhatsApp Chat
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Param			q			query	string	true	"Words to search for"
+//	@Param			chat		query	string	false	"Chat phone"
+//	@Param			sender		query	string	false	"Sender phone"
+//	@Param			since		query	string	false	"RFC 3339 start date"
+//	@Param			until		query	string	false	"RFC 3339 end date"
+//	@Param			limit		query	int		false	"Maximum matches, 20 by default"
+//	@Param			offset		query	int		false	"Matches to skip"
+//	@Produce		json
+//	@Success		200	{object}	searchMessagesResponse	"Matching messages"
+//	@Failure		400	{object}	response.Error
+//	@Failure		404	{object}	response.Error
+//	@Router			/{instanceId}/messages/search [get]
+func (h *searchMessagesHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var query searchMessagesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if strings.TrimSpace(query.Q) == "" {
+		response.ErrorResponse(c, http.StatusBadRequest, "Query must not be empty")
+		return
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	matches, err := h.messageService.SearchMessages(repository.MessageSearch{
+		InstanceID: instanceID,
+		Query:      query.Q,
+		ChatJID:    query.Chat,
+		SenderJID:  query.Sender,
+		Since:      query.Since,
+		Until:      query.Until,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	data := []response.MessageMatch{}
+	for _, match := range *matches {
+		data = append(data, response.NewMessageMatchResponse(match.Message, match.Snippet))
+	}
+
+	response.Response(c, http.StatusOK, searchMessagesResponse{
+		Matches: data,
+	})
+}
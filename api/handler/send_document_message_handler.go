@@ -6,6 +6,7 @@ import (
 	"zapmeow/api/model"
 	"zapmeow/api/response"
 	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vincent-petithory/dataurl"
@@ -24,15 +25,18 @@ type sendDocumentMessageResponse struct {
 type sendDocumentMessageHandler struct {
 	whatsAppService service.WhatsAppService
 	messageService  service.MessageService
+	mediaService    service.MediaService
 }
 
 func NewSendDocumentMessageHandler(
 	whatsAppService service.WhatsAppService,
 	messageService service.MessageService,
+	mediaService service.MediaService,
 ) *sendDocumentMessageHandler {
 	return &sendDocumentMessageHandler{
 		whatsAppService: whatsAppService,
 		messageService:  messageService,
+		mediaService:    mediaService,
 	}
 }
 
@@ -46,6 +50,8 @@ func NewSendDocumentMessageHandler(
 //	@Accept			json
 //	@Produce		json
 //	@Success		200	{object}	sendDocumentMessageResponse	"Message Send Response"
+//	@Failure		413	{object}	response.Error	"Media exceeds the size limit"
+//	@Failure		415	{object}	response.Error	"Media content does not match its type, or the type is not allowed"
 //	@Router			/{instanceId}/chat/send/document [post]
 func (h *sendDocumentMessageHandler) Handler(c *gin.Context) {
 	instanceID := c.Param("instanceId")
@@ -60,8 +66,14 @@ func (h *sendDocumentMessageHandler) Handler(c *gin.Context) {
 		return
 	}
 
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Document))
+
 	var body sendDocumentMessageBody
 	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
 		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
 		return
 	}
@@ -84,6 +96,12 @@ func (h *sendDocumentMessageHandler) Handler(c *gin.Context) {
 		return
 	}
 
+	mimitype, err = h.mediaService.ValidateMedia(whatsapp.Document, documentURL.Data, mimitype)
+	if err != nil {
+		response.ErrorResponse(c, mediaErrorStatus(err), err.Error())
+		return
+	}
+
 	resp, err := h.whatsAppService.SendDocumentMessage(instance, jid, documentURL, mimitype, body.Filename)
 	if err != nil {
 		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -102,14 +120,17 @@ func (h *sendDocumentMessageHandler) Handler(c *gin.Context) {
 	}
 
 	message := model.Message{
-		FromMe:     true,
-		ChatJID:    jid.User,
-		SenderJID:  resp.Sender.User,
-		InstanceID: instanceID,
-		Timestamp:  resp.Timestamp,
-		MessageID:  resp.ID,
-		MediaType:  "document",
-		MediaPath:  path,
+		FromMe:         true,
+		ChatJID:        jid.User,
+		SenderJID:      resp.Sender.User,
+		RemoteJID:      jid.String(),
+		ParticipantJID: resp.Sender.String(),
+		InstanceID:     instanceID,
+		Timestamp:      resp.Timestamp,
+		MessageID:      resp.ID,
+		MediaType:      "document",
+		MediaPath:      path,
+		MediaStatus:    model.MediaStatusReady,
 	}
 
 	err = h.messageService.CreateMessage(&message)
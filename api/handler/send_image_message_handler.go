@@ -6,6 +6,7 @@ import (
 	"zapmeow/api/model"
 	"zapmeow/api/response"
 	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vincent-petithory/dataurl"
@@ -23,15 +24,18 @@ type sendImageMessageResponse struct {
 type sendImageMessageHandler struct {
 	whatsAppService service.WhatsAppService
 	messageService  service.MessageService
+	mediaService    service.MediaService
 }
 
 func NewSendImageMessageHandler(
 	whatsAppService service.WhatsAppService,
 	messageService service.MessageService,
+	mediaService service.MediaService,
 ) *sendImageMessageHandler {
 	return &sendImageMessageHandler{
 		whatsAppService: whatsAppService,
 		messageService:  messageService,
+		mediaService:    mediaService,
 	}
 }
 
@@ -45,6 +49,8 @@ func NewSendImageMessageHandler(
 //	@Accept			json
 //	@Produce		json
 //	@Success		200	{object}	sendImageMessageResponse	"Message Send Response"
+//	@Failure		413	{object}	response.Error	"Media exceeds the size limit"
+//	@Failure		415	{object}	response.Error	"Media content does not match its type, or the type is not allowed"
 //	@Router			/{instanceId}/chat/send/image [post]
 func (h *sendImageMessageHandler) Handler(c *gin.Context) {
 	instanceID := c.Param("instanceId")
@@ -59,8 +65,14 @@ func (h *sendImageMessageHandler) Handler(c *gin.Context) {
 		return
 	}
 
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Image))
+
 	var body sendImageMessageBody
 	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
 		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
 		return
 	}
@@ -83,6 +95,12 @@ func (h *sendImageMessageHandler) Handler(c *gin.Context) {
 		return
 	}
 
+	mimitype, err = h.mediaService.ValidateMedia(whatsapp.Image, imageURL.Data, mimitype)
+	if err != nil {
+		response.ErrorResponse(c, mediaErrorStatus(err), err.Error())
+		return
+	}
+
 	resp, err := h.whatsAppService.SendImageMessage(instance, jid, imageURL, mimitype)
 	if err != nil {
 		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -101,14 +119,17 @@ func (h *sendImageMessageHandler) Handler(c *gin.Context) {
 	}
 
 	message := model.Message{
-		FromMe:     true,
-		ChatJID:    jid.User,
-		SenderJID:  resp.Sender.User,
-		InstanceID: instanceID,
-		Timestamp:  resp.Timestamp,
-		MessageID:  resp.ID,
-		MediaType:  "image",
-		MediaPath:  path,
+		FromMe:         true,
+		ChatJID:        jid.User,
+		SenderJID:      resp.Sender.User,
+		RemoteJID:      jid.String(),
+		ParticipantJID: resp.Sender.String(),
+		InstanceID:     instanceID,
+		Timestamp:      resp.Timestamp,
+		MessageID:      resp.ID,
+		MediaType:      "image",
+		MediaPath:      path,
+		MediaStatus:    model.MediaStatusReady,
 	}
 
 	err = h.messageService.CreateMessage(&message)
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vincent-petithory/dataurl"
+)
+
+type sendImageStatusBody struct {
+	Base64  string `json:"base64"`
+	Caption string `json:"caption"`
+}
+
+type sendImageStatusHandler struct {
+	whatsAppService service.WhatsAppService
+	statusService   service.StatusService
+	mediaService    service.MediaService
+}
+
+func NewSendImageStatusHandler(
+	whatsAppService service.WhatsAppService,
+	statusService service.StatusService,
+	mediaService service.MediaService,
+) *sendImageStatusHandler {
+	return &sendImageStatusHandler{
+		whatsAppService: whatsAppService,
+		statusService:   statusService,
+		mediaService:    mediaService,
+	}
+}
+
+// Post Image Status on WhatsApp
+//
+//	@Summary		Post Image Status on WhatsApp
+//	@Description	Posts an image status update with an optional caption.
+//	@Description	It reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.
+//	@Tags			WhatsApp Status
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	sendImageStatusBody	true	"Image status body"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	sendStatusResponse	"Posted status"
+//	@Failure		413	{object}	response.Error	"Media exceeds the size limit"
+//	@Failure		415	{object}	response.Error	"Media content does not match its type, or the type is not allowed"
+//	@Router			/{instanceId}/status/image [post]
+func (h *sendImageStatusHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Image))
+
+	var body sendImageStatusBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	mimitype, err := helper.GetMimeTypeFromDataURI(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	imageURL, err := dataurl.DecodeString(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	mimitype, err = h.mediaService.ValidateMedia(whatsapp.Image, imageURL.Data, mimitype)
+	if err != nil {
+		response.ErrorResponse(c, mediaErrorStatus(err), err.Error())
+		return
+	}
+
+	resp, err := h.whatsAppService.SendImageStatus(instance, imageURL, mimitype, body.Caption)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	path, err := helper.SaveMedia(
+		instanceID,
+		"status_"+resp.ID,
+		imageURL.Data,
+		mimitype,
+	)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := model.Status{
+		InstanceID:     instanceID,
+		StatusID:       resp.ID,
+		SenderJID:      resp.Sender.User,
+		ParticipantJID: resp.Sender.String(),
+		FromMe:         true,
+		Timestamp:      resp.Timestamp,
+		Caption:        body.Caption,
+		MediaType:      "image",
+		MediaPath:      path,
+		MediaStatus:    model.MediaStatusReady,
+	}
+
+	err = h.statusService.CreateStatus(&status)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, sendStatusResponse{
+		Status: response.NewStatusResponse(status),
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vincent-petithory/dataurl"
+)
+
+type sendNewsletterImageBody struct {
+	ID      string `json:"id"`
+	Base64  string `json:"base64"`
+	Caption string `json:"caption"`
+}
+
+type sendNewsletterImageHandler struct {
+	whatsAppService service.WhatsAppService
+	mediaService    service.MediaService
+}
+
+func NewSendNewsletterImageHandler(
+	whatsAppService service.WhatsAppService,
+	mediaService service.MediaService,
+) *sendNewsletterImageHandler {
+	return &sendNewsletterImageHandler{
+		whatsAppService: whatsAppService,
+		mediaService:    mediaService,
+	}
+}
+
+// Send Image to WhatsApp Newsletter
+//
+//	@Summary		Send Image to WhatsApp Newsletter
+//	@Description	Posts an image with an optional caption to a channel the instance owns or administers.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string					true	"Instance ID"
+//	@Param			data		body	sendNewsletterImageBody	true	"Newsletter ID, image and caption"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	newsletterMessageResponse	"Posted message"
+//	@Failure		400	{object}	response.Error
+//	@Failure		413	{object}	response.Error	"Media exceeds the size limit"
+//	@Failure		415	{object}	response.Error	"Media content does not match its type, or the type is not allowed"
+//	@Router			/{instanceId}/newsletter/send/image [post]
+func (h *sendNewsletterImageHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Image))
+
+	var body sendNewsletterImageBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := makeNewsletterJID(body.ID)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, errInvalidNewsletter.Error())
+		return
+	}
+
+	mimitype, err := helper.GetMimeTypeFromDataURI(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	imageURL, err := dataurl.DecodeString(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	mimitype, err = h.mediaService.ValidateMedia(whatsapp.Image, imageURL.Data, mimitype)
+	if err != nil {
+		response.ErrorResponse(c, mediaErrorStatus(err), err.Error())
+		return
+	}
+
+	resp, err := h.whatsAppService.SendNewsletterImage(instance, jid, imageURL, mimitype, body.Caption)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	mediaType := whatsapp.Image
+	response.Response(c, http.StatusOK, newsletterMessageResponse{
+		Message: response.NewNewsletterMessageResponse(whatsapp.NewsletterMessage{
+			ServerID:  resp.ServerID,
+			MessageID: resp.ID,
+			Timestamp: resp.Timestamp,
+			Caption:   body.Caption,
+			MediaType: &mediaType,
+		}),
+	})
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type sendNewsletterTextBody struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+type sendNewsletterTextHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewSendNewsletterTextHandler(
+	whatsAppService service.WhatsAppService,
+) *sendNewsletterTextHandler {
+	return &sendNewsletterTextHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Send Text to WhatsApp Newsletter
+//
+//	@Summary		Send Text to WhatsApp Newsletter
+//	@Description	Posts a text to a channel the instance owns or administers.
+//	@Tags			WhatsApp Newsletter
+//	@Param			instanceId	path	string					true	"Instance ID"
+//	@Param			data		body	sendNewsletterTextBody	true	"Newsletter ID and text"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	newsletterMessageResponse	"Posted message"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/newsletter/send/text [post]
+func (h *sendNewsletterTextHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body sendNewsletterTextBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	jid, ok := makeNewsletterJID(body.ID)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, errInvalidNewsletter.Error())
+		return
+	}
+	if strings.TrimSpace(body.Text) == "" {
+		response.ErrorResponse(c, http.StatusBadRequest, "Text is required")
+		return
+	}
+
+	resp, err := h.whatsAppService.SendNewsletterText(instance, jid, body.Text)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, newsletterMessageResponse{
+		Message: response.NewNewsletterMessageResponse(whatsapp.NewsletterMessage{
+			ServerID:  resp.ServerID,
+			MessageID: resp.ID,
+			Timestamp: resp.Timestamp,
+			Body:      body.Text,
+		}),
+	})
+}
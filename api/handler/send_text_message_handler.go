@@ -73,13 +73,15 @@ func (h *sendTextMessageHandler) Handler(c *gin.Context) {
 	}
 
 	message := model.Message{
-		MessageID:  resp.ID,
-		ChatJID:    jid.User,
-		SenderJID:  resp.Sender.User,
-		InstanceID: instanceID,
-		Body:       body.Text,
-		Timestamp:  resp.Timestamp,
-		FromMe:     true,
+		MessageID:      resp.ID,
+		ChatJID:        jid.User,
+		SenderJID:      resp.Sender.User,
+		RemoteJID:      jid.String(),
+		ParticipantJID: resp.Sender.String(),
+		InstanceID:     instanceID,
+		Body:           body.Text,
+		Timestamp:      resp.Timestamp,
+		FromMe:         true,
 	}
 
 	err = h.messageService.CreateMessage(&message)
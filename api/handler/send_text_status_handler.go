@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxStatusTextLength = 700
+	maxStatusFont       = 10
+)
+
+type sendTextStatusBody struct {
+	Text string `json:"text"`
+	// colors are "#RRGGBB"; the background defaults to black and the text to white
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+	Font            int32  `json:"font"`
+}
+
+type sendStatusResponse struct {
+	Status response.Status `json:"status"`
+}
+
+type sendTextStatusHandler struct {
+	whatsAppService service.WhatsAppService
+	statusService   service.StatusService
+}
+
+func NewSendTextStatusHandler(
+	whatsAppService service.WhatsAppService,
+	statusService service.StatusService,
+) *sendTextStatusHandler {
+	return &sendTextStatusHandler{
+		whatsAppService: whatsAppService,
+		statusService:   statusService,
+	}
+}
+
+// Post Text Status on WhatsApp
+//
+//	@Summary		Post Text Status on WhatsApp
+//	@Description	Posts a text status update over a solid background.
+//	@Description	It reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.
+//	@Tags			WhatsApp Status
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	sendTextStatusBody	true	"Text, colors and font"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	sendStatusResponse	"Posted status"
+//	@Failure		400	{object}	response.Error
+//	@Router			/{instanceId}/status/text [post]
+func (h *sendTextStatusHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body sendTextStatusBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if strings.TrimSpace(body.Text) == "" {
+		response.ErrorResponse(c, http.StatusBadRequest, "Text is required")
+		return
+	}
+	if utf8.RuneCountInString(body.Text) > maxStatusTextLength {
+		response.ErrorResponse(c, http.StatusBadRequest, "Text is too long")
+		return
+	}
+	if body.Font < 0 || body.Font > maxStatusFont {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid font")
+		return
+	}
+
+	background, ok := parseColor(body.BackgroundColor, 0xff000000)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid background color")
+		return
+	}
+	textColor, ok := parseColor(body.TextColor, 0xffffffff)
+	if !ok {
+		response.ErrorResponse(c, http.StatusBadRequest, "Invalid text color")
+		return
+	}
+
+	resp, err := h.whatsAppService.SendTextStatus(instance, whatsapp.TextStatus{
+		Text:            body.Text,
+		BackgroundColor: background,
+		TextColor:       textColor,
+		Font:            body.Font,
+	})
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := model.Status{
+		InstanceID:     instanceID,
+		StatusID:       resp.ID,
+		SenderJID:      resp.Sender.User,
+		ParticipantJID: resp.Sender.String(),
+		FromMe:         true,
+		Timestamp:      resp.Timestamp,
+		Body:           body.Text,
+	}
+
+	err = h.statusService.CreateStatus(&status)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, sendStatusResponse{
+		Status: response.NewStatusResponse(status),
+	})
+}
+
+// parseColor reads a "#RRGGBB" color as opaque ARGB, returning fallback
+// when the color is empty.
+func parseColor(color string, fallback uint32) (uint32, bool) {
+	if color == "" {
+		return fallback, true
+	}
+
+	hex, found := strings.CutPrefix(color, "#")
+	if !found || len(hex) != 6 {
+		return 0, false
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return 0xff000000 | uint32(rgb), true
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vincent-petithory/dataurl"
+)
+
+type sendVideoStatusBody struct {
+	Base64  string `json:"base64"`
+	Caption string `json:"caption"`
+}
+
+type sendVideoStatusHandler struct {
+	whatsAppService service.WhatsAppService
+	statusService   service.StatusService
+	mediaService    service.MediaService
+}
+
+func NewSendVideoStatusHandler(
+	whatsAppService service.WhatsAppService,
+	statusService service.StatusService,
+	mediaService service.MediaService,
+) *sendVideoStatusHandler {
+	return &sendVideoStatusHandler{
+		whatsAppService: whatsAppService,
+		statusService:   statusService,
+		mediaService:    mediaService,
+	}
+}
+
+// Post Video Status on WhatsApp
+//
+//	@Summary		Post Video Status on WhatsApp
+//	@Description	Posts a video status update with an optional caption.
+//	@Description	It reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.
+//	@Tags			WhatsApp Status
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	sendVideoStatusBody	true	"Video status body"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	sendStatusResponse	"Posted status"
+//	@Failure		413	{object}	response.Error	"Media exceeds the size limit"
+//	@Failure		415	{object}	response.Error	"Media content does not match its type, or the type is not allowed"
+//	@Router			/{instanceId}/status/video [post]
+func (h *sendVideoStatusHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Video))
+
+	var body sendVideoStatusBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	mimitype, err := helper.GetMimeTypeFromDataURI(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	videoURL, err := dataurl.DecodeString(body.Base64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	mimitype, err = h.mediaService.ValidateMedia(whatsapp.Video, videoURL.Data, mimitype)
+	if err != nil {
+		response.ErrorResponse(c, mediaErrorStatus(err), err.Error())
+		return
+	}
+
+	resp, err := h.whatsAppService.SendVideoStatus(instance, videoURL, mimitype, body.Caption)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	path, err := helper.SaveMedia(
+		instanceID,
+		"status_"+resp.ID,
+		videoURL.Data,
+		mimitype,
+	)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := model.Status{
+		InstanceID:     instanceID,
+		StatusID:       resp.ID,
+		SenderJID:      resp.Sender.User,
+		ParticipantJID: resp.Sender.String(),
+		FromMe:         true,
+		Timestamp:      resp.Timestamp,
+		Caption:        body.Caption,
+		MediaType:      "video",
+		MediaPath:      path,
+		MediaStatus:    model.MediaStatusReady,
+	}
+
+	err = h.statusService.CreateStatus(&status)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, sendStatusResponse{
+		Status: response.NewStatusResponse(status),
+	})
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+)
+
+type setPrivacySettingsHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewSetPrivacySettingsHandler(
+	whatsAppService service.WhatsAppService,
+) *setPrivacySettingsHandler {
+	return &setPrivacySettingsHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Set Privacy Settings
+//
+//	@Summary		Set Privacy Settings
+//	@Description	Changes the given privacy settings and leaves the omitted ones as they are.
+//	@Description	last_seen, profile, status and group_add take all, contacts, contact_blacklist or none.
+//	@Description	online takes all or match_last_seen, read_receipts all or none, and call_add all or known.
+//	@Description	status is who can see the about text, not who receives status updates, see the status audience.
+//	@Tags			WhatsApp Profile
+//	@Param			instanceId	path	string						true	"Instance ID"
+//	@Param			data		body	whatsapp.PrivacySettings	true	"Privacy settings"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	privacySettingsResponse	"Privacy settings"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/profile/privacy [post]
+func (h *setPrivacySettingsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body whatsapp.PrivacySettings
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	settings, err := h.whatsAppService.SetPrivacySettings(instance, body)
+	if errors.Is(err, whatsapp.ErrInvalidPrivacySetting) {
+		response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, privacySettingsResponse{
+		Privacy: settings,
+	})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"unicode/utf8"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAboutLength is the longest about text WhatsApp accepts, in characters.
+const maxAboutLength = 139
+
+type setProfileAboutBody struct {
+	About string `json:"about"`
+}
+
+type setProfileAboutHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewSetProfileAboutHandler(
+	whatsAppService service.WhatsAppService,
+) *setProfileAboutHandler {
+	return &setProfileAboutHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Set Profile About
+//
+//	@Summary		Set Profile About
+//	@Description	Changes the about text of the instance profile.
+//	@Tags			WhatsApp Profile
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	setProfileAboutBody	true	"About text"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	setProfileAboutBody	"About text"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/profile/about [post]
+func (h *setProfileAboutHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body setProfileAboutBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if utf8.RuneCountInString(body.About) > maxAboutLength {
+		response.ErrorResponse(c, http.StatusBadRequest, "About must have at most 139 characters")
+		return
+	}
+
+	if err := h.whatsAppService.SetAbout(instance, body.About); err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, body)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"unicode/utf8"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPushNameLength is the longest name WhatsApp accepts, in characters.
+const maxPushNameLength = 25
+
+type setProfileNameBody struct {
+	Name string `json:"name"`
+}
+
+type setProfileNameHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewSetProfileNameHandler(
+	whatsAppService service.WhatsAppService,
+) *setProfileNameHandler {
+	return &setProfileNameHandler{
+		whatsAppService: whatsAppService,
+	}
+}
+
+// Set Profile Name
+//
+//	@Summary		Set Profile Name
+//	@Description	Changes the name the instance shows to other users.
+//	@Tags			WhatsApp Profile
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	setProfileNameBody	true	"Name"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	setProfileNameBody	"Name"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Router			/{instanceId}/profile/name [post]
+func (h *setProfileNameHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	var body setProfileNameBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if body.Name == "" || utf8.RuneCountInString(body.Name) > maxPushNameLength {
+		response.ErrorResponse(c, http.StatusBadRequest, "Name must have between 1 and 25 characters")
+		return
+	}
+
+	if err := h.whatsAppService.SetPushName(instance, body.Name); err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, body)
+}
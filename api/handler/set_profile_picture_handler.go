@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"image"
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/whatsapp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vincent-petithory/dataurl"
+)
+
+type profilePictureCrop struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type setProfilePictureBody struct {
+	// Base64 is a data URL of the picture, empty to remove it
+	Base64 string              `json:"base64"`
+	Crop   *profilePictureCrop `json:"crop"`
+}
+
+type setProfilePictureResponse struct {
+	PictureID string `json:"picture_id"`
+}
+
+type setProfilePictureHandler struct {
+	whatsAppService service.WhatsAppService
+	mediaService    service.MediaService
+}
+
+func NewSetProfilePictureHandler(
+	whatsAppService service.WhatsAppService,
+	mediaService service.MediaService,
+) *setProfilePictureHandler {
+	return &setProfilePictureHandler{
+		whatsAppService: whatsAppService,
+		mediaService:    mediaService,
+	}
+}
+
+// Set Profile Picture
+//
+//	@Summary		Set Profile Picture
+//	@Description	Changes the profile picture of the instance, or removes it when base64 is empty.
+//	@Description	The picture is cropped to the given area, or to the largest centered square, and scaled down to 640 pixels.
+//	@Tags			WhatsApp Profile
+//	@Param			instanceId	path	string					true	"Instance ID"
+//	@Param			data		body	setProfilePictureBody	true	"Picture and crop area"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	setProfilePictureResponse	"New picture ID"
+//	@Failure		400	{object}	response.Error
+//	@Failure		401	{object}	response.Error
+//	@Failure		413	{object}	response.Error
+//	@Router			/{instanceId}/profile/picture [post]
+func (h *setProfilePictureHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.whatsAppService.IsAuthenticated(instance) {
+		response.ErrorResponse(c, http.StatusUnauthorized, "unautenticated")
+		return
+	}
+
+	limitMediaBody(c, h.mediaService.MaxMediaSize(whatsapp.Image))
+
+	var body setProfilePictureBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if isBodyTooLarge(err) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, service.ErrMediaTooLarge.Error())
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	var picture []byte
+	if body.Base64 != "" {
+		imageURL, err := dataurl.DecodeString(body.Base64)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var crop *image.Rectangle
+		if body.Crop != nil {
+			if body.Crop.Width <= 0 || body.Crop.Height <= 0 {
+				response.ErrorResponse(c, http.StatusBadRequest, helper.ErrInvalidCrop.Error())
+				return
+			}
+			area := image.Rect(
+				body.Crop.X,
+				body.Crop.Y,
+				body.Crop.X+body.Crop.Width,
+				body.Crop.Y+body.Crop.Height,
+			)
+			crop = &area
+		}
+
+		picture, err = helper.MakeProfilePicture(imageURL.Data, crop)
+		if errors.Is(err, helper.ErrInvalidCrop) || errors.Is(err, image.ErrFormat) {
+			response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	pictureID, err := h.whatsAppService.SetProfilePicture(instance, picture)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, setProfilePictureResponse{
+		PictureID: pictureID,
+	})
+}
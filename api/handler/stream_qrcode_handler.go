@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"zapmeow/api/helper"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+	"zapmeow/pkg/pubsub"
+	"zapmeow/pkg/zapmeow"
+
+	"github.com/gin-gonic/gin"
+)
+
+type streamQrCodeHandler struct {
+	app             *zapmeow.ZapMeow
+	whatsAppService service.WhatsAppService
+	accountService  service.AccountService
+}
+
+func NewStreamQrCodeHandler(
+	app *zapmeow.ZapMeow,
+	whatsAppService service.WhatsAppService,
+	accountService service.AccountService,
+) *streamQrCodeHandler {
+	return &streamQrCodeHandler{
+		app:             app,
+		whatsAppService: whatsAppService,
+		accountService:  accountService,
+	}
+}
+
+// Stream QR Codes for WhatsApp Login
+//
+//	@Summary		Stream WhatsApp QR Codes
+//	@Description	Pushes every new QR code as a Server-Sent Event ("code", with the raw code and a base64 PNG), then the pairing result ("paired", "connected", "timeout", "rate-limit" or "error"). The stream ends once the login succeeds or fails.
+//	@Tags			WhatsApp Login
+//	@Param			instanceId	path	string	true	"Instance ID"
+//	@Produce		text/event-stream
+//	@Success		200	{object}	getQrCodeResponse	"QR Code events"
+//	@Router			/{instanceId}/qrcode/stream [get]
+func (h *streamQrCodeHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+
+	// subscribe before the instance starts so the first code isn't missed
+	events, unsubscribe := h.whatsAppService.SubscribePairing(instanceID)
+	defer unsubscribe()
+
+	instance, err := h.whatsAppService.GetInstance(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	if h.whatsAppService.IsAuthenticated(instance) {
+		c.SSEvent("connected", gin.H{})
+		return
+	}
+
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err == nil && account != nil && account.QrCode != "" {
+		c.SSEvent("code", makeQrCodeEvent(account.QrCode))
+		c.Writer.Flush()
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		// an open stream would hold the server shutdown until pairing ends
+		case <-*h.app.StopCh:
+			return false
+		case message := <-events:
+			if message.Event == "code" {
+				data := message.Data.(map[string]interface{})
+				c.SSEvent(message.Event, makeQrCodeEvent(data["qrcode"].(string)))
+				return true
+			}
+
+			data := message.Data
+			if data == nil {
+				data = gin.H{}
+			}
+			c.SSEvent(message.Event, data)
+			return !isPairingFinished(message)
+		}
+	})
+}
+
+func makeQrCodeEvent(code string) getQrCodeResponse {
+	image, _ := helper.MakeQrCodeDataURI(code, qrCodeImageSize)
+	return getQrCodeResponse{
+		QrCode: code,
+		Image:  image,
+	}
+}
+
+func isPairingFinished(message pubsub.Message) bool {
+	switch message.Event {
+	case "connected", "timeout", "rate-limit", "error":
+		return true
+	}
+	return false
+}
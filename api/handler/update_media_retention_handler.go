@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updateMediaRetentionBody struct {
+	MediaType     string `json:"media_type"`
+	MaxAgeDays    int    `json:"max_age_days"`
+	MaxBytes      int64  `json:"max_bytes"`
+	KeepThumbnail bool   `json:"keep_thumbnail"`
+}
+
+type updateMediaRetentionResponse struct {
+	Policy response.MediaRetentionPolicy `json:"policy"`
+}
+
+type updateMediaRetentionHandler struct {
+	accountService service.AccountService
+	mediaService   service.MediaService
+}
+
+func NewUpdateMediaRetentionHandler(
+	accountService service.AccountService,
+	mediaService service.MediaService,
+) *updateMediaRetentionHandler {
+	return &updateMediaRetentionHandler{
+		accountService: accountService,
+		mediaService:   mediaService,
+	}
+}
+
+// Update Media Retention Policy
+//
+//	@Summary		Update Media Retention Policy
+//	@Description	Creates or replaces the retention policy of a media type for the specified instance. An empty media type applies to all media, and max bytes is only enforced on that policy.
+//	@Tags			WhatsApp Media
+//	@Param			instanceId	path	string						true	"Instance ID"
+//	@Param			data		body	updateMediaRetentionBody	true	"Retention policy"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	updateMediaRetentionResponse	"Media retention policy"
+//	@Router			/{instanceId}/media/retention [put]
+func (h *updateMediaRetentionHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var body updateMediaRetentionBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if body.MaxAgeDays < 0 || body.MaxBytes < 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Retention limits must not be negative")
+		return
+	}
+
+	policy := model.MediaRetentionPolicy{
+		InstanceID:    instanceID,
+		MediaType:     body.MediaType,
+		MaxAgeDays:    body.MaxAgeDays,
+		MaxBytes:      body.MaxBytes,
+		KeepThumbnail: body.KeepThumbnail,
+	}
+
+	err = h.mediaService.SaveRetentionPolicy(&policy)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, updateMediaRetentionResponse{
+		Policy: response.NewMediaRetentionPolicyResponse(policy),
+	})
+}
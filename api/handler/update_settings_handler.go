@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"zapmeow/api/model"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updateSettingsBody struct {
+	CustomerName      string   `json:"customer_name"`
+	Tags              []string `json:"tags"`
+	WebhookURL        *string  `json:"webhook_url"`
+	HistorySync       *bool    `json:"history_sync"`
+	MaxMessageSync    *int     `json:"max_message_sync"`
+	AutoRead          *bool    `json:"auto_read"`
+	RejectCalls       *bool    `json:"reject_calls"`
+	RejectCallMessage *string  `json:"reject_call_message"`
+}
+
+type updateSettingsHandler struct {
+	accountService  service.AccountService
+	settingsService service.SettingsService
+}
+
+func NewUpdateSettingsHandler(
+	accountService service.AccountService,
+	settingsService service.SettingsService,
+) *updateSettingsHandler {
+	return &updateSettingsHandler{
+		accountService:  accountService,
+		settingsService: settingsService,
+	}
+}
+
+// Update Instance Settings
+//
+//	@Summary		Update Instance Settings
+//	@Description	Replaces the metadata and setting overrides of the specified instance. A null setting falls back to the global config. Changes apply to the next event, without restarting the instance.
+//	@Tags			WhatsApp Instances
+//	@Param			instanceId	path	string				true	"Instance ID"
+//	@Param			data		body	updateSettingsBody	true	"Instance settings"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	getSettingsResponse	"Instance settings"
+//	@Router			/{instanceId}/settings [put]
+func (h *updateSettingsHandler) Handler(c *gin.Context) {
+	instanceID := c.Param("instanceId")
+	account, err := h.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if account == nil {
+		response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	var body updateSettingsBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, "Error trying to validate infos. ")
+		return
+	}
+
+	if body.MaxMessageSync != nil && *body.MaxMessageSync < 0 {
+		response.ErrorResponse(c, http.StatusBadRequest, "Max message sync must not be negative")
+		return
+	}
+
+	if body.WebhookURL != nil && *body.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(*body.WebhookURL); err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook url")
+			return
+		}
+	}
+
+	account.CustomerName = body.CustomerName
+	account.Tags = body.Tags
+	account.Settings = model.AccountSettings{
+		WebhookURL:        body.WebhookURL,
+		HistorySync:       body.HistorySync,
+		MaxMessageSync:    body.MaxMessageSync,
+		AutoRead:          body.AutoRead,
+		RejectCalls:       body.RejectCalls,
+		RejectCallMessage: body.RejectCallMessage,
+	}
+
+	err = h.accountService.UpdateAccountSettings(account)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(instanceID)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Response(c, http.StatusOK, makeSettingsResponse(*account, settings))
+}
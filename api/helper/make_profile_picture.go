@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// ProfilePictureSize is the side of the square WhatsApp shows profile
+// pictures at; larger pictures are scaled down to it.
+const ProfilePictureSize = 640
+
+var ErrInvalidCrop = errors.New("crop area is outside of the image")
+
+// MakeProfilePicture crops the image to the given area, or to the largest
+// centered square when crop is nil, and encodes it as a JPEG no larger than
+// ProfilePictureSize on each side.
+func MakeProfilePicture(data []byte, crop *image.Rectangle) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	var area image.Rectangle
+	if crop != nil {
+		area = crop.Add(bounds.Min)
+		if area.Empty() || !area.In(bounds) {
+			return nil, ErrInvalidCrop
+		}
+	} else {
+		side := Min(bounds.Dx(), bounds.Dy())
+		x := bounds.Min.X + (bounds.Dx()-side)/2
+		y := bounds.Min.Y + (bounds.Dy()-side)/2
+		area = image.Rect(x, y, x+side, y+side)
+	}
+
+	// keep the aspect ratio of the crop, fitting its longest side
+	width, height := area.Dx(), area.Dy()
+	if longest := max(width, height); longest > ProfilePictureSize {
+		width = max(1, width*ProfilePictureSize/longest)
+		height = max(1, height*ProfilePictureSize/longest)
+	}
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, resize(src, area, width, height), &jpeg.Options{Quality: 90})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resize scales the area of src to width by height, averaging every source
+// pixel that falls into a destination pixel.
+func resize(src image.Image, area image.Rectangle, width int, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := area.Min.Y + y*area.Dy()/height
+		y1 := max(y0+1, area.Min.Y+(y+1)*area.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := area.Min.X + x*area.Dx()/width
+			x1 := max(x0+1, area.Min.X+(x+1)*area.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// JPEG has no alpha, so transparent pixels turn white
+			white := 0xffff - a/n
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r/n + white) >> 8),
+				G: uint8((g/n + white) >> 8),
+				B: uint8((b/n + white) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+func MakeQrCodePNG(code string, size int) ([]byte, error) {
+	return qrcode.Encode(code, qrcode.Medium, size)
+}
+
+func MakeQrCodeDataURI(code string, size int) (string, error) {
+	png, err := MakeQrCodePNG(code, size)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
+
+func MakeQrCodeSVG(code string) (string, error) {
+	qr, err := qrcode.New(code, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+
+	bitmap := qr.Bitmap()
+	var svg strings.Builder
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges"><rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="`,
+		len(bitmap),
+		len(bitmap),
+	)
+	for y, row := range bitmap {
+		for x, black := range row {
+			if black {
+				fmt.Fprintf(&svg, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	svg.WriteString(`"/></svg>`)
+	return svg.String(), nil
+}
@@ -0,0 +1,12 @@
+package helper
+
+import "net/http"
+
+func SaveThumbnail(instanceID string, messageID string, data []byte) (string, error) {
+	return SaveMedia(
+		instanceID,
+		messageID+"_thumbnail",
+		data,
+		http.DetectContentType(data),
+	)
+}
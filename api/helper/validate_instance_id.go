@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var instanceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// reservedInstanceIDs would be shadowed by the static routes under /api, see
+// ReserveRouteSegments.
+var reservedInstanceIDs []string
+
+// IsValidInstanceID keeps instance IDs safe to use in URLs and as part of
+// the storage path of the instance.
+func IsValidInstanceID(instanceID string) bool {
+	return instanceIDPattern.MatchString(instanceID) &&
+		!slices.Contains(reservedInstanceIDs, instanceID)
+}
+
+// ReserveRouteSegments reserves the first segment after prefix of every
+// static route path, as an instance with that ID would be shadowed by it.
+// It is called once the routes are set up, before serving.
+func ReserveRouteSegments(paths []string, prefix string) {
+	for _, path := range paths {
+		rest, found := strings.CutPrefix(path, prefix+"/")
+		if !found {
+			continue
+		}
+
+		segment, _, _ := strings.Cut(rest, "/")
+		if segment == "" || strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			continue
+		}
+		if !slices.Contains(reservedInstanceIDs, segment) {
+			reservedInstanceIDs = append(reservedInstanceIDs, segment)
+		}
+	}
+}
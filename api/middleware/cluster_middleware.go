@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	nodeHeader      = "X-Zapmeow-Node"
+	forwardedHeader = "X-Zapmeow-Forwarded-By"
+)
+
+// RouteInstance sends requests for instances held by another node to that
+// node, either proxying them or redirecting the client there. Instances no
+// node holds are served, and so claimed, by this one.
+func RouteInstance(clusterService service.ClusterService, forward bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !clusterService.Enabled() {
+			c.Next()
+			return
+		}
+
+		instanceID := c.Param("instanceId")
+		node, err := clusterService.Owner(instanceID)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if node == nil || node.ID == clusterService.NodeID() {
+			c.Next()
+			return
+		}
+
+		c.Header(nodeHeader, node.ID)
+
+		// the owner died and its lease hasn't expired yet
+		if node.Addr == "" {
+			response.ErrorResponse(c, http.StatusServiceUnavailable, "Instance is moving to another node")
+			return
+		}
+
+		// both nodes think the other one holds the instance
+		if c.GetHeader(forwardedHeader) != "" {
+			response.ErrorResponse(c, http.StatusServiceUnavailable, "Instance is moving to another node")
+			return
+		}
+
+		if !forward {
+			c.Redirect(http.StatusTemporaryRedirect, node.Addr+c.Request.URL.RequestURI())
+			c.Abort()
+			return
+		}
+
+		target, err := url.Parse(node.Addr)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		proxy := httputil.NewSingleHostReverseProxy(target)
+		// flush right away so qrcode streams keep working through the proxy
+		proxy.FlushInterval = -1
+		c.Request.Header.Set(forwardedHeader, clusterService.NodeID())
+		proxy.ServeHTTP(c.Writer, c.Request)
+		c.Abort()
+	}
+}
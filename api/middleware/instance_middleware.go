@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"zapmeow/api/response"
+	"zapmeow/api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireInstance rejects requests for instance IDs that were never created
+// through the instances API, instead of creating them on the fly.
+func RequireInstance(accountService service.AccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		instanceID := c.Param("instanceId")
+		account, err := accountService.GetAccountByInstanceID(instanceID)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if account == nil {
+			response.ErrorResponse(c, http.StatusNotFound, "Instance not found")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,109 @@
+package migration
+
+import (
+	"time"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// The structs below are snapshots of the models when the migration was
+// written, so later model changes don't alter what it creates.
+
+type account0001 struct {
+	gorm.Model
+	User                  string
+	RawAgent              uint8
+	Device                uint16
+	Integrator            uint16
+	Server                string
+	QrCode                string
+	Status                string
+	WasSynced             bool
+	InstanceID            string
+	DisconnectReason      string
+	ReconnectAttempts     int
+	CustomerName          string
+	Tags                  []string `gorm:"serializer:json"`
+	SettingWebhookURL     *string  `gorm:"column:setting_webhook_url"`
+	SettingHistorySync    *bool    `gorm:"column:setting_history_sync"`
+	SettingMaxMessageSync *int     `gorm:"column:setting_max_message_sync"`
+	SettingAutoRead       *bool    `gorm:"column:setting_auto_read"`
+	SettingRejectCalls    *bool    `gorm:"column:setting_reject_calls"`
+}
+
+func (account0001) TableName() string {
+	return "accounts"
+}
+
+type message0001 struct {
+	gorm.Model
+	SenderJID      string `gorm:"column:sender_jid"`
+	ChatJID        string `gorm:"column:chat_jid"`
+	RemoteJID      string `gorm:"column:remote_jid"`
+	ParticipantJID string `gorm:"column:participant_jid"`
+	InstanceID     string
+	MessageID      string
+	Timestamp      time.Time
+	Body           string
+	MediaType      string
+	MediaPath      string
+	MediaStatus    string
+	ThumbnailPath  string
+	RawMessage     []byte
+	FromMe         bool
+}
+
+func (message0001) TableName() string {
+	return "messages"
+}
+
+type mediaRetentionPolicy0001 struct {
+	gorm.Model
+	InstanceID    string
+	MediaType     string
+	MaxAgeDays    int
+	MaxBytes      int64
+	KeepThumbnail bool
+}
+
+func (mediaRetentionPolicy0001) TableName() string {
+	return "media_retention_policies"
+}
+
+type mediaPurge0001 struct {
+	gorm.Model
+	InstanceID string
+	MessageID  string
+	MediaType  string
+	MediaPath  string
+	Size       int64
+	Reason     string
+}
+
+func (mediaPurge0001) TableName() string {
+	return "media_purges"
+}
+
+// createTables is the baseline. It uses AutoMigrate rather than CreateTable
+// so databases created before versioned migrations are brought up to it.
+var createTables = database.Migration{
+	Version: 1,
+	Name:    "create_tables",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&account0001{},
+			&message0001{},
+			&mediaRetentionPolicy0001{},
+			&mediaPurge0001{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(
+			&mediaPurge0001{},
+			&mediaRetentionPolicy0001{},
+			&message0001{},
+			&account0001{},
+		)
+	},
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var addMessageIndexes = database.Migration{
+	Version: 2,
+	Name:    "add_message_indexes",
+	Up: func(tx *gorm.DB) error {
+		err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_messages_chat ON messages (instance_id, chat_jid, timestamp)").Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_messages_message_id ON messages (message_id)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		err := tx.Exec("DROP INDEX IF EXISTS idx_messages_message_id").Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("DROP INDEX IF EXISTS idx_messages_chat").Error
+	},
+}
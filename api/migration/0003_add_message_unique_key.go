@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// addMessageUniqueKey drops the duplicates left by redelivered events and
+// repeated history syncs, keeping the oldest row, before adding the key.
+var addMessageUniqueKey = database.Migration{
+	Version: 3,
+	Name:    "add_message_unique_key",
+	Up: func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			DELETE FROM messages
+			WHERE id NOT IN (
+				SELECT MIN(id) FROM messages
+				GROUP BY instance_id, chat_jid, message_id
+			)
+		`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_unique ON messages (instance_id, chat_jid, message_id)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP INDEX IF EXISTS idx_messages_unique").Error
+	},
+}
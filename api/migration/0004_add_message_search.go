@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"strings"
+	"time"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type message0004 struct {
+	Caption  string
+	EditedAt *time.Time
+	Revoked  bool
+}
+
+func (message0004) TableName() string {
+	return "messages"
+}
+
+var messageColumns0004 = []string{"Caption", "EditedAt", "Revoked"}
+
+// addMessageSearch indexes the body and caption of every message. SQLite
+// keeps an FTS5 table in sync through triggers, Postgres a generated
+// tsvector column. A SQLite built without FTS5 gets no index, and the search
+// falls back to matching the text.
+var addMessageSearch = database.Migration{
+	Version: 4,
+	Name:    "add_message_search",
+	Up: func(tx *gorm.DB) error {
+		for _, column := range messageColumns0004 {
+			if err := tx.Migrator().AddColumn(&message0004{}, column); err != nil {
+				return err
+			}
+		}
+
+		if tx.Dialector.Name() == "postgres" {
+			err := tx.Exec(`
+				ALTER TABLE messages ADD COLUMN search tsvector
+				GENERATED ALWAYS AS (
+					to_tsvector('simple', coalesce(body, '') || ' ' || coalesce(caption, ''))
+				) STORED
+			`).Error
+			if err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN (search)").Error
+		}
+
+		err := tx.Exec(`
+			CREATE VIRTUAL TABLE messages_fts USING fts5(
+				body, caption,
+				content='messages', content_rowid='id',
+				tokenize='unicode61 remove_diacritics 2'
+			)
+		`).Error
+		if err != nil {
+			if strings.Contains(err.Error(), "no such module") {
+				return nil
+			}
+			return err
+		}
+
+		statements := []string{
+			`CREATE TRIGGER messages_fts_insert AFTER INSERT ON messages BEGIN
+				INSERT INTO messages_fts (rowid, body, caption) VALUES (new.id, new.body, new.caption);
+			END`,
+			`CREATE TRIGGER messages_fts_delete AFTER DELETE ON messages BEGIN
+				INSERT INTO messages_fts (messages_fts, rowid, body, caption) VALUES ('delete', old.id, old.body, old.caption);
+			END`,
+			`CREATE TRIGGER messages_fts_update AFTER UPDATE OF body, caption ON messages BEGIN
+				INSERT INTO messages_fts (messages_fts, rowid, body, caption) VALUES ('delete', old.id, old.body, old.caption);
+				INSERT INTO messages_fts (rowid, body, caption) VALUES (new.id, new.body, new.caption);
+			END`,
+			`INSERT INTO messages_fts (messages_fts) VALUES ('rebuild')`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		var statements []string
+		if tx.Dialector.Name() == "postgres" {
+			statements = []string{
+				"DROP INDEX IF EXISTS idx_messages_search",
+				"ALTER TABLE messages DROP COLUMN IF EXISTS search",
+			}
+		} else {
+			statements = []string{
+				"DROP TRIGGER IF EXISTS messages_fts_update",
+				"DROP TRIGGER IF EXISTS messages_fts_delete",
+				"DROP TRIGGER IF EXISTS messages_fts_insert",
+				"DROP TABLE IF EXISTS messages_fts",
+			}
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		// the sqlite migrator drops columns by rebuilding the table, which
+		// would lose its indexes
+		for _, column := range []string{"caption", "edited_at", "revoked"} {
+			if err := tx.Exec("ALTER TABLE messages DROP COLUMN " + column).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"time"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type chat0005 struct {
+	gorm.Model
+	InstanceID    string
+	ChatJID       string `gorm:"column:chat_jid"`
+	RemoteJID     string `gorm:"column:remote_jid"`
+	Name          string
+	UnreadCount   int
+	Archived      bool
+	Pinned        bool
+	MutedUntil    *time.Time
+	LastMessageAt *time.Time
+}
+
+func (chat0005) TableName() string {
+	return "chats"
+}
+
+var createChats = database.Migration{
+	Version: 5,
+	Name:    "create_chats",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Migrator().CreateTable(&chat0005{}); err != nil {
+			return err
+		}
+		err := tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_chats_unique ON chats (instance_id, chat_jid)").Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_chats_last_message ON chats (instance_id, last_message_at)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&chat0005{})
+	},
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type chat0006 struct {
+	MarkedUnread bool
+}
+
+func (chat0006) TableName() string {
+	return "chats"
+}
+
+var addChatMarkedUnread = database.Migration{
+	Version: 6,
+	Name:    "add_chat_marked_unread",
+	Up: func(tx *gorm.DB) error {
+		return tx.Migrator().AddColumn(&chat0006{}, "MarkedUnread")
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE chats DROP COLUMN marked_unread").Error
+	},
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type contact0007 struct {
+	gorm.Model
+	InstanceID       string
+	ContactJID       string `gorm:"column:contact_jid"`
+	RemoteJID        string `gorm:"column:remote_jid"`
+	PushName         string
+	BusinessName     string
+	FullName         string
+	FirstName        string
+	PictureURL       string
+	PictureUpdatedAt *time.Time
+}
+
+func (contact0007) TableName() string {
+	return "contacts"
+}
+
+var createContacts = database.Migration{
+	Version: 7,
+	Name:    "create_contacts",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Migrator().CreateTable(&contact0007{}); err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_contacts_unique ON contacts (instance_id, contact_jid)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&contact0007{})
+	},
+}
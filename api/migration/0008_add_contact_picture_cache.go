@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type contact0008 struct {
+	Status      string
+	PictureID   string
+	PicturePath string
+}
+
+func (contact0008) TableName() string {
+	return "contacts"
+}
+
+var contactColumns0008 = []string{"Status", "PictureID", "PicturePath"}
+
+var addContactPictureCache = database.Migration{
+	Version: 8,
+	Name:    "add_contact_picture_cache",
+	Up: func(tx *gorm.DB) error {
+		for _, column := range contactColumns0008 {
+			if err := tx.Migrator().AddColumn(&contact0008{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, column := range []string{"status", "picture_id", "picture_path"} {
+			if err := tx.Exec("ALTER TABLE contacts DROP COLUMN " + column).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type account0009 struct {
+	SettingRejectCallMessage *string `gorm:"column:setting_reject_call_message"`
+}
+
+func (account0009) TableName() string {
+	return "accounts"
+}
+
+var addRejectCallMessage = database.Migration{
+	Version: 9,
+	Name:    "add_reject_call_message",
+	Up: func(tx *gorm.DB) error {
+		return tx.Migrator().AddColumn(&account0009{}, "SettingRejectCallMessage")
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE accounts DROP COLUMN setting_reject_call_message").Error
+	},
+}
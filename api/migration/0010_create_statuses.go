@@ -0,0 +1,48 @@
+package migration
+
+import (
+	"time"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type status0010 struct {
+	gorm.Model
+	InstanceID     string
+	StatusID       string
+	SenderJID      string `gorm:"column:sender_jid"`
+	ParticipantJID string `gorm:"column:participant_jid"`
+	FromMe         bool
+	Timestamp      time.Time
+	Body           string
+	Caption        string
+	MediaType      string
+	MediaPath      string
+	MediaStatus    string
+	ThumbnailPath  string
+	RawMessage     []byte
+	Revoked        bool
+}
+
+func (status0010) TableName() string {
+	return "statuses"
+}
+
+var createStatuses = database.Migration{
+	Version: 10,
+	Name:    "create_statuses",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Migrator().CreateTable(&status0010{}); err != nil {
+			return err
+		}
+		err := tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_statuses_unique ON statuses (instance_id, sender_jid, status_id)").Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_statuses_timestamp ON statuses (instance_id, timestamp)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&status0010{})
+	},
+}
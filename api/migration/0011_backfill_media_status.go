@@ -0,0 +1,25 @@
+package migration
+
+import (
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// backfillMediaStatus marks the media stored before media statuses existed
+// as ready, so the retention worker can expire it like any other.
+var backfillMediaStatus = database.Migration{
+	Version: 11,
+	Name:    "backfill_media_status",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec(`
+			UPDATE messages SET media_status = 'READY'
+			WHERE (media_status IS NULL OR media_status = '') AND media_path <> ''
+		`).Error
+	},
+	// the backfilled rows can't be told apart from the others, and READY is
+	// what they were all along
+	Down: func(tx *gorm.DB) error {
+		return nil
+	},
+}
@@ -0,0 +1,19 @@
+package migration
+
+import "zapmeow/pkg/database"
+
+// Migrations lists every schema change in the order they were written. Once
+// released, a migration must never change; add a new one instead.
+var Migrations = []database.Migration{
+	createTables,
+	addMessageIndexes,
+	addMessageUniqueKey,
+	addMessageSearch,
+	createChats,
+	addChatMarkedUnread,
+	createContacts,
+	addContactPictureCache,
+	addRejectCallMessage,
+	createStatuses,
+	backfillMediaStatus,
+}
@@ -4,13 +4,30 @@ import "gorm.io/gorm"
 
 type Account struct {
 	gorm.Model
-	User       string
-	RawAgent   uint8
-	Device     uint16
-	Integrator uint16
-	Server     string
-	QrCode     string
-	Status     string
-	WasSynced  bool
-	InstanceID string
+	User              string
+	RawAgent          uint8
+	Device            uint16
+	Integrator        uint16
+	Server            string
+	QrCode            string
+	Status            string
+	WasSynced         bool
+	InstanceID        string
+	DisconnectReason  string
+	ReconnectAttempts int
+	CustomerName      string
+	Tags              []string        `gorm:"serializer:json"`
+	Settings          AccountSettings `gorm:"embedded;embeddedPrefix:setting_"`
+}
+
+// AccountSettings overrides the global config for a single instance. A nil
+// field falls back to the global value.
+type AccountSettings struct {
+	WebhookURL     *string
+	HistorySync    *bool
+	MaxMessageSync *int
+	AutoRead       *bool
+	RejectCalls    *bool
+	// RejectCallMessage is sent to callers after their call is rejected
+	RejectCallMessage *string
 }
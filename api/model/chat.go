@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MutedForever is the MutedUntil of chats muted without an end.
+var MutedForever = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+type Chat struct {
+	gorm.Model
+	InstanceID    string
+	ChatJID       string `gorm:"column:chat_jid"`
+	RemoteJID     string `gorm:"column:remote_jid"` // full chat JID
+	Name          string
+	UnreadCount   int
+	MarkedUnread  bool
+	Archived      bool
+	Pinned        bool
+	MutedUntil    *time.Time
+	LastMessageAt *time.Time
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Contact struct {
+	gorm.Model
+	InstanceID       string
+	ContactJID       string `gorm:"column:contact_jid"`
+	RemoteJID        string `gorm:"column:remote_jid"` // full contact JID
+	PushName         string
+	BusinessName     string
+	FullName         string // from the address book of the phone
+	FirstName        string
+	Status           string // the about text
+	PictureID        string
+	PictureURL       string
+	PicturePath      string // downloaded picture, empty until fetched
+	PictureUpdatedAt *time.Time
+}
@@ -0,0 +1,25 @@
+package model
+
+import "gorm.io/gorm"
+
+// MediaRetentionPolicy overrides the global retention settings. An empty
+// MediaType applies to every media type of the instance, and MaxBytes is
+// only enforced on that instance-wide policy.
+type MediaRetentionPolicy struct {
+	gorm.Model
+	InstanceID    string
+	MediaType     string
+	MaxAgeDays    int
+	MaxBytes      int64
+	KeepThumbnail bool
+}
+
+type MediaPurge struct {
+	gorm.Model
+	InstanceID string
+	MessageID  string
+	MediaType  string
+	MediaPath  string
+	Size       int64
+	Reason     string
+}
@@ -6,15 +6,31 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	MediaStatusPending = "PENDING"
+	MediaStatusReady   = "READY"
+	MediaStatusRetry   = "RETRY"
+	MediaStatusFailed  = "FAILED"
+	MediaStatusExpired = "EXPIRED"
+)
+
 type Message struct {
 	gorm.Model
-	SenderJID  string `gorm:"column:sender_jid"`
-	ChatJID    string `gorm:"column:chat_jid"`
-	InstanceID string
-	MessageID  string
-	Timestamp  time.Time
-	Body       string
-	MediaType  string // text, image, ptt, audio, document
-	MediaPath  string
-	FromMe     bool
+	SenderJID      string `gorm:"column:sender_jid"`
+	ChatJID        string `gorm:"column:chat_jid"`
+	RemoteJID      string `gorm:"column:remote_jid"`      // full chat JID
+	ParticipantJID string `gorm:"column:participant_jid"` // full sender JID
+	InstanceID     string
+	MessageID      string
+	Timestamp      time.Time
+	Body           string
+	Caption        string
+	MediaType      string // text, image, video, ptt, audio, document
+	MediaPath      string
+	MediaStatus    string
+	ThumbnailPath  string
+	RawMessage     []byte // protobuf used to download the media later
+	FromMe         bool
+	EditedAt       *time.Time
+	Revoked        bool
 }
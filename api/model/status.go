@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status is a status update posted by a contact or by the instance itself.
+// They are kept apart from chat messages.
+type Status struct {
+	gorm.Model
+	InstanceID     string
+	StatusID       string
+	SenderJID      string `gorm:"column:sender_jid"`
+	ParticipantJID string `gorm:"column:participant_jid"` // full sender JID
+	FromMe         bool
+	Timestamp      time.Time
+	Body           string
+	Caption        string
+	MediaType      string // image, video or audio, empty for text
+	MediaPath      string
+	MediaStatus    string
+	ThumbnailPath  string
+	RawMessage     []byte // protobuf used to download the media later
+	Revoked        bool
+}
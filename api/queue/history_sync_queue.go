@@ -17,7 +17,7 @@ type historySyncQueue struct {
 
 type HistorySyncQueue interface {
 	Enqueue(item HistorySyncQueueData) error
-	Dequeue() (*HistorySyncQueueData, error)
+	Dequeue(instanceID string) (*HistorySyncQueueData, error)
 }
 
 func NewHistorySyncQueue(app *zapmeow.ZapMeow) *historySyncQueue {
@@ -26,6 +26,12 @@ func NewHistorySyncQueue(app *zapmeow.ZapMeow) *historySyncQueue {
 	}
 }
 
+// name gives every instance its own queue, so only the node running the
+// instance takes its jobs, and they follow the instance to another node.
+func (q *historySyncQueue) name(instanceID string) string {
+	return q.app.Config.HistorySyncQueueName + ":" + instanceID
+}
+
 func (q *historySyncQueue) Enqueue(item HistorySyncQueueData) error {
 	jsonData, err := json.Marshal(item)
 	if err != nil {
@@ -35,11 +41,11 @@ func (q *historySyncQueue) Enqueue(item HistorySyncQueueData) error {
 		return err
 	}
 
-	return q.app.Queue.Enqueue(q.app.Config.HistorySyncQueueName, jsonData)
+	return q.app.Queue.Enqueue(q.name(item.InstanceID), jsonData)
 }
 
-func (q *historySyncQueue) Dequeue() (*HistorySyncQueueData, error) {
-	result, err := q.app.Queue.Dequeue(q.app.Config.HistorySyncQueueName)
+func (q *historySyncQueue) Dequeue(instanceID string) (*HistorySyncQueueData, error) {
+	result, err := q.app.Queue.Dequeue(q.name(instanceID))
 	if err != nil {
 		logger.Error("Error dequeuing history sync", logger.Fields{
 			"error": err,
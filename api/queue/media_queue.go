@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"encoding/json"
+	"zapmeow/pkg/logger"
+	"zapmeow/pkg/zapmeow"
+)
+
+type MediaQueueData struct {
+	InstanceID string
+	ID         uint
+	// Status tells that ID refers to a status instead of a message
+	Status bool
+}
+
+type mediaQueue struct {
+	app *zapmeow.ZapMeow
+}
+
+type MediaQueue interface {
+	Enqueue(item MediaQueueData) error
+	Dequeue(instanceID string) (*MediaQueueData, error)
+}
+
+func NewMediaQueue(app *zapmeow.ZapMeow) *mediaQueue {
+	return &mediaQueue{
+		app: app,
+	}
+}
+
+// name gives every instance its own queue, so only the node running the
+// instance takes its jobs, and they follow the instance to another node.
+func (q *mediaQueue) name(instanceID string) string {
+	return q.app.Config.MediaQueueName + ":" + instanceID
+}
+
+func (q *mediaQueue) Enqueue(item MediaQueueData) error {
+	jsonData, err := json.Marshal(item)
+	if err != nil {
+		logger.Error("Error enqueue media.", logger.Fields{
+			"error": err,
+		})
+		return err
+	}
+
+	return q.app.Queue.Enqueue(q.name(item.InstanceID), jsonData)
+}
+
+func (q *mediaQueue) Dequeue(instanceID string) (*MediaQueueData, error) {
+	result, err := q.app.Queue.Dequeue(q.name(instanceID))
+	if err != nil {
+		logger.Error("Error dequeuing media", logger.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	var data MediaQueueData
+	err = json.Unmarshal(result, &data)
+	if err != nil {
+		logger.Error("Error unmarshal media.", logger.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	return &data, nil
+}
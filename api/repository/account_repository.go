@@ -9,9 +9,12 @@ import (
 
 type AccountRepository interface {
 	CreateAccount(account *model.Account) error
+	GetAccounts() ([]model.Account, error)
 	GetConnectedAccounts() ([]model.Account, error)
 	GetAccountByInstanceID(instanceID string) (*model.Account, error)
 	UpdateAccount(instanceID string, data map[string]interface{}) error
+	UpdateAccountSettings(account *model.Account) error
+	DeleteAccount(instanceID string) error
 }
 
 type accountRepository struct {
@@ -26,6 +29,14 @@ func (repo *accountRepository) CreateAccount(account *model.Account) error {
 	return repo.database.Client().Create(account).Error
 }
 
+func (repo *accountRepository) GetAccounts() ([]model.Account, error) {
+	var accounts []model.Account
+	if result := repo.database.Client().Find(&accounts); result.Error != nil {
+		return nil, result.Error
+	}
+	return accounts, nil
+}
+
 func (repo *accountRepository) GetConnectedAccounts() ([]model.Account, error) {
 	var accounts []model.Account
 	repo.database.Client().Where("status = ?", "CONNECTED").Find(&accounts)
@@ -56,3 +67,27 @@ func (repo *accountRepository) UpdateAccount(instanceID string, data map[string]
 
 	return nil
 }
+
+func (repo *accountRepository) UpdateAccountSettings(account *model.Account) error {
+	// select the columns so nil settings are written back as NULL
+	return repo.database.Client().
+		Model(account).
+		Select(
+			"customer_name",
+			"tags",
+			"setting_webhook_url",
+			"setting_history_sync",
+			"setting_max_message_sync",
+			"setting_auto_read",
+			"setting_reject_calls",
+			"setting_reject_call_message",
+		).
+		Updates(account).Error
+}
+
+func (repo *accountRepository) DeleteAccount(instanceID string) error {
+	return repo.database.Client().
+		Unscoped().
+		Where("instance_id = ?", instanceID).
+		Delete(&model.Account{}).Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+)
+
+func TestUpdateAccountSettingsSavesRejectCallMessage(t *testing.T) {
+	db := database.NewDatabase("sqlite://" + filepath.Join(t.TempDir(), "zapmeow.db"))
+	if err := db.Client().AutoMigrate(&model.Account{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewAccountRepository(db)
+
+	account := model.Account{InstanceID: "a"}
+	if err := repo.CreateAccount(&account); err != nil {
+		t.Fatal(err)
+	}
+
+	rejectCalls := true
+	message := "Sorry, we can't take calls"
+	account.Settings.RejectCalls = &rejectCalls
+	account.Settings.RejectCallMessage = &message
+	if err := repo.UpdateAccountSettings(&account); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repo.GetAccountByInstanceID("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Settings.RejectCallMessage == nil || *stored.Settings.RejectCallMessage != message {
+		t.Fatalf("reject call message was not saved, got %v", stored.Settings.RejectCallMessage)
+	}
+
+	// clearing the setting falls back to the global message
+	account.Settings.RejectCallMessage = nil
+	if err := repo.UpdateAccountSettings(&account); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err = repo.GetAccountByInstanceID("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Settings.RejectCallMessage != nil {
+		t.Fatalf("reject call message was not cleared, got %q", *stored.Settings.RejectCallMessage)
+	}
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ChatSortLastMessage = "last_message"
+	ChatSortName        = "name"
+	ChatSortUnread      = "unread"
+)
+
+type ChatRepository interface {
+	TouchChat(chat *model.Chat, read bool) error
+	UpsertChat(chat *model.Chat) error
+	SaveChatState(chat *model.Chat, columns ...string) error
+	UpdateChat(instanceID string, chatJID string, data map[string]interface{}) error
+	GetChat(instanceID string, chatJID string) (*model.Chat, error)
+	GetChats(query ChatQuery) (*[]model.Chat, int64, error)
+	DeleteChat(instanceID string, chatJID string) error
+	DeleteChatsByInstanceID(instanceID string) error
+}
+
+// ChatQuery selects a page of chats. A nil Archived returns every chat.
+type ChatQuery struct {
+	InstanceID string
+	Archived   *bool
+	Sort       string
+	Limit      int
+	Offset     int
+}
+
+type chatRepository struct {
+	database database.Database
+}
+
+func NewChatRepository(database database.Database) *chatRepository {
+	return &chatRepository{database: database}
+}
+
+// latestMessageAt keeps the newest time, as messages may be stored out of order.
+var latestMessageAt = gorm.Expr(
+	"CASE WHEN chats.last_message_at IS NULL OR chats.last_message_at < excluded.last_message_at THEN excluded.last_message_at ELSE chats.last_message_at END",
+)
+
+var chatKey = []clause.Column{
+	{Name: "instance_id"},
+	{Name: "chat_jid"},
+}
+
+// TouchChat records a new message in the chat, creating it when missing.
+// The unread count grows by one, or is cleared when read is set.
+func (repo *chatRepository) TouchChat(chat *model.Chat, read bool) error {
+	updates := map[string]interface{}{
+		"last_message_at": latestMessageAt,
+		"updated_at":      gorm.Expr("excluded.updated_at"),
+	}
+	if read {
+		chat.UnreadCount = 0
+		updates["unread_count"] = 0
+		updates["marked_unread"] = false
+	} else {
+		chat.UnreadCount = 1
+		updates["unread_count"] = gorm.Expr("chats.unread_count + 1")
+	}
+
+	return repo.database.Client().Clauses(clause.OnConflict{
+		Columns:   chatKey,
+		DoUpdates: clause.Assignments(updates),
+	}).Create(chat).Error
+}
+
+// UpsertChat overwrites the chat metadata, keeping the stored name when the
+// new one is empty.
+func (repo *chatRepository) UpsertChat(chat *model.Chat) error {
+	updates := map[string]interface{}{
+		"remote_jid":      gorm.Expr("excluded.remote_jid"),
+		"unread_count":    gorm.Expr("excluded.unread_count"),
+		"marked_unread":   gorm.Expr("excluded.marked_unread"),
+		"archived":        gorm.Expr("excluded.archived"),
+		"pinned":          gorm.Expr("excluded.pinned"),
+		"muted_until":     gorm.Expr("excluded.muted_until"),
+		"last_message_at": latestMessageAt,
+		"updated_at":      gorm.Expr("excluded.updated_at"),
+	}
+	if chat.Name != "" {
+		updates["name"] = gorm.Expr("excluded.name")
+	}
+
+	return repo.database.Client().Clauses(clause.OnConflict{
+		Columns:   chatKey,
+		DoUpdates: clause.Assignments(updates),
+	}).Create(chat).Error
+}
+
+// SaveChatState writes the given columns of the chat, creating it when missing.
+func (repo *chatRepository) SaveChatState(chat *model.Chat, columns ...string) error {
+	return repo.database.Client().Clauses(clause.OnConflict{
+		Columns:   chatKey,
+		DoUpdates: clause.AssignmentColumns(append([]string{"updated_at"}, columns...)),
+	}).Create(chat).Error
+}
+
+func (repo *chatRepository) UpdateChat(instanceID string, chatJID string, data map[string]interface{}) error {
+	return repo.database.Client().Model(&model.Chat{}).
+		Where("instance_id = ? AND chat_jid = ?", instanceID, chatJID).
+		Updates(data).Error
+}
+
+func (repo *chatRepository) GetChat(instanceID string, chatJID string) (*model.Chat, error) {
+	var chat model.Chat
+	result := repo.database.Client().Where("instance_id = ? AND chat_jid = ?", instanceID, chatJID).First(&chat)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &chat, nil
+}
+
+func (repo *chatRepository) GetChats(query ChatQuery) (*[]model.Chat, int64, error) {
+	tx := repo.database.Client().Model(&model.Chat{}).Where("instance_id = ?", query.InstanceID)
+	if query.Archived != nil {
+		tx = tx.Where("archived = ?", *query.Archived)
+	}
+
+	var total int64
+	if result := tx.Count(&total); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	switch query.Sort {
+	case ChatSortName:
+		// unnamed chats go last
+		tx = tx.Order("name = '', name ASC")
+	case ChatSortUnread:
+		tx = tx.Order("unread_count DESC, marked_unread DESC, last_message_at IS NULL, last_message_at DESC")
+	default:
+		tx = tx.Order("last_message_at IS NULL, last_message_at DESC")
+	}
+	tx = tx.Order("id ASC")
+
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	var chats []model.Chat
+	if result := tx.Find(&chats); result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return &chats, total, nil
+}
+
+func (repo *chatRepository) DeleteChat(instanceID string, chatJID string) error {
+	return repo.database.Client().Where("instance_id = ? AND chat_jid = ?", instanceID, chatJID).Unscoped().Delete(&model.Chat{}).Error
+}
+
+func (repo *chatRepository) DeleteChatsByInstanceID(instanceID string) error {
+	return repo.database.Client().Where("instance_id = ?", instanceID).Unscoped().Delete(&model.Chat{}).Error
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"strings"
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// contactBatchSize keeps an import of the whole address book under the
+// sqlite limit of bound variables per statement.
+const contactBatchSize = 500
+
+type ContactRepository interface {
+	SaveContacts(contacts *[]model.Contact, columns ...string) error
+	GetContact(instanceID string, contactJID string) (*model.Contact, error)
+	GetContactsByJIDs(instanceID string, contactJIDs []string) (*[]model.Contact, error)
+	GetContacts(query ContactQuery) (*[]model.Contact, int64, error)
+	DeleteContactsByInstanceID(instanceID string) error
+}
+
+// ContactQuery selects a page of contacts. Search matches any name or the
+// phone, ignoring case.
+type ContactQuery struct {
+	InstanceID string
+	Search     string
+	Limit      int
+	Offset     int
+}
+
+type contactRepository struct {
+	database database.Database
+}
+
+func NewContactRepository(database database.Database) *contactRepository {
+	return &contactRepository{database: database}
+}
+
+// SaveContacts writes the given columns of every contact, creating the ones
+// that are missing.
+func (repo *contactRepository) SaveContacts(contacts *[]model.Contact, columns ...string) error {
+	// a conflict can only be resolved once per statement
+	seen := map[string]int{}
+	unique := []model.Contact{}
+	for _, contact := range *contacts {
+		key := contact.InstanceID + "|" + contact.ContactJID
+		if i, ok := seen[key]; ok {
+			unique[i] = contact
+			continue
+		}
+		seen[key] = len(unique)
+		unique = append(unique, contact)
+	}
+
+	*contacts = unique
+	if len(unique) == 0 {
+		return nil
+	}
+
+	return repo.database.Client().Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "instance_id"},
+			{Name: "contact_jid"},
+		},
+		DoUpdates: clause.AssignmentColumns(append([]string{"remote_jid", "updated_at"}, columns...)),
+	}).CreateInBatches(contacts, contactBatchSize).Error
+}
+
+func (repo *contactRepository) GetContact(instanceID string, contactJID string) (*model.Contact, error) {
+	var contact model.Contact
+	result := repo.database.Client().Where("instance_id = ? AND contact_jid = ?", instanceID, contactJID).First(&contact)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &contact, nil
+}
+
+func (repo *contactRepository) GetContactsByJIDs(instanceID string, contactJIDs []string) (*[]model.Contact, error) {
+	var contacts []model.Contact
+	result := repo.database.Client().Where("instance_id = ? AND contact_jid IN ?", instanceID, contactJIDs).Find(&contacts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &contacts, nil
+}
+
+// likeEscaper makes '%' and '_' typed by the user match themselves.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (repo *contactRepository) GetContacts(query ContactQuery) (*[]model.Contact, int64, error) {
+	tx := repo.database.Client().Model(&model.Contact{}).Where("instance_id = ?", query.InstanceID)
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		tx = tx.Where(
+			`LOWER(full_name) LIKE LOWER(?) ESCAPE '\' OR LOWER(push_name) LIKE LOWER(?) ESCAPE '\' OR `+
+				`LOWER(business_name) LIKE LOWER(?) ESCAPE '\' OR contact_jid LIKE ? ESCAPE '\'`,
+			pattern, pattern, pattern, pattern,
+		)
+	}
+
+	var total int64
+	if result := tx.Count(&total); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	// saved contacts first, by the name the phone shows for them
+	tx = tx.Order("full_name = '', full_name ASC, push_name = '', push_name ASC, contact_jid ASC")
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	var contacts []model.Contact
+	if result := tx.Find(&contacts); result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return &contacts, total, nil
+}
+
+func (repo *contactRepository) DeleteContactsByInstanceID(instanceID string) error {
+	return repo.database.Client().Where("instance_id = ?", instanceID).Unscoped().Delete(&model.Contact{}).Error
+}
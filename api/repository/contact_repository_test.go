@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+)
+
+func TestGetContactsSearchesWildcardsAsText(t *testing.T) {
+	db := database.NewDatabase("sqlite://" + filepath.Join(t.TempDir(), "zapmeow.db"))
+	if err := db.Client().AutoMigrate(&model.Contact{}); err != nil {
+		t.Fatal(err)
+	}
+	contacts := []model.Contact{
+		{InstanceID: "a", ContactJID: "1@s.whatsapp.net", FullName: "100% Ana"},
+		{InstanceID: "a", ContactJID: "2@s.whatsapp.net", FullName: "1000 Bia"},
+		{InstanceID: "a", ContactJID: "3@s.whatsapp.net", PushName: "ana_b"},
+		{InstanceID: "a", ContactJID: "4@s.whatsapp.net", PushName: "anaxb"},
+		{InstanceID: "a", ContactJID: "5@s.whatsapp.net", PushName: `c\d`},
+	}
+	if err := db.Client().Create(&contacts).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewContactRepository(db)
+
+	for search, want := range map[string]string{
+		"0%":   "1@s.whatsapp.net",
+		"a_b":  "3@s.whatsapp.net",
+		`c\d`:  "5@s.whatsapp.net",
+		"ANA_": "3@s.whatsapp.net",
+	} {
+		found, total, err := repo.GetContacts(ContactQuery{InstanceID: "a", Search: search})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || len(*found) != 1 || (*found)[0].ContactJID != want {
+			t.Errorf("search %q found %d contacts, want only %s", search, total, want)
+		}
+	}
+}
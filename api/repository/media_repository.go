@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+)
+
+type MediaRepository interface {
+	CreateMediaPurge(purge *model.MediaPurge) error
+	GetRetentionPolicies(instanceID string) ([]model.MediaRetentionPolicy, error)
+	SaveRetentionPolicy(policy *model.MediaRetentionPolicy) error
+	DeleteInstanceMedia(instanceID string) error
+}
+
+type mediaRepository struct {
+	database database.Database
+}
+
+func NewMediaRepository(database database.Database) *mediaRepository {
+	return &mediaRepository{database: database}
+}
+
+func (repo *mediaRepository) CreateMediaPurge(purge *model.MediaPurge) error {
+	return repo.database.Client().Create(purge).Error
+}
+
+func (repo *mediaRepository) GetRetentionPolicies(instanceID string) ([]model.MediaRetentionPolicy, error) {
+	var policies []model.MediaRetentionPolicy
+	if result := repo.database.Client().Where("instance_id = ?", instanceID).Find(&policies); result.Error != nil {
+		return nil, result.Error
+	}
+	return policies, nil
+}
+
+func (repo *mediaRepository) SaveRetentionPolicy(policy *model.MediaRetentionPolicy) error {
+	var existing model.MediaRetentionPolicy
+	result := repo.database.Client().
+		Where("instance_id = ? AND media_type = ?", policy.InstanceID, policy.MediaType).
+		Limit(1).
+		Find(&existing)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		policy.ID = existing.ID
+		policy.CreatedAt = existing.CreatedAt
+	}
+	return repo.database.Client().Save(policy).Error
+}
+
+func (repo *mediaRepository) DeleteInstanceMedia(instanceID string) error {
+	err := repo.database.Client().
+		Unscoped().
+		Where("instance_id = ?", instanceID).
+		Delete(&model.MediaRetentionPolicy{}).Error
+	if err != nil {
+		return err
+	}
+
+	return repo.database.Client().
+		Unscoped().
+		Where("instance_id = ?", instanceID).
+		Delete(&model.MediaPurge{}).Error
+}
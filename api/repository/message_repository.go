@@ -1,16 +1,80 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+	"time"
 	"zapmeow/api/model"
 	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MessageRepository interface {
 	CreateMessage(message *model.Message) error
 	CreateMessages(messages *[]model.Message) error
 	GetChatMessages(instanceID string, chatJID string) (*[]model.Message, error)
+	QueryChatMessages(query MessageQuery) (*[]model.Message, error)
 	CountChatMessages(instanceID string, chatJID string) (int64, error)
+	GetMessage(id uint) (*model.Message, error)
+	GetMessageByMessageID(instanceID string, messageID string) (*model.Message, error)
+	GetMediaMessages(instanceID string) (*[]model.Message, error)
+	UpdateMessage(id uint, data map[string]interface{}) error
+	UpdateChatMessage(instanceID string, chatJID string, messageID string, data map[string]interface{}) error
+	SearchMessages(search MessageSearch) (*[]MessageMatch, error)
 	DeleteMessagesByInstanceID(instanceID string) error
+	GetChatMediaFiles(instanceID string, chatJID string) ([]string, error)
+	DeleteChatMessages(instanceID string, chatJID string) error
+}
+
+// MessageQuery selects a page of chat messages. Before and After are
+// exclusive cursors on (timestamp, id); a zero Limit returns every match.
+type MessageQuery struct {
+	InstanceID string
+	ChatJID    string
+	Before     *MessageCursor
+	After      *MessageCursor
+	Limit      int
+	SenderJID  string
+	FromMe     *bool
+	MediaType  string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// MessageSearch finds messages whose body or caption contains every word
+// of Query, best matches first.
+type MessageSearch struct {
+	InstanceID string
+	Query      string
+	ChatJID    string
+	SenderJID  string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+// MessageMatch is a found message with the matched words highlighted.
+type MessageMatch struct {
+	model.Message
+	Snippet string
+}
+
+const (
+	snippetStart = "<mark>"
+	snippetEnd   = "</mark>"
+)
+
+var ErrCursorChatMismatch = errors.New("cursor belongs to another chat")
+
+// MessageCursor points between the messages of a chat. ID is zero for a
+// timestamp cursor, and ChatJID is empty for one that fits any chat.
+type MessageCursor struct {
+	ChatJID   string
+	Timestamp time.Time
+	ID        uint
 }
 
 type messageRepository struct {
@@ -21,12 +85,55 @@ func NewMessageRepository(database database.Database) *messageRepository {
 	return &messageRepository{database: database}
 }
 
+// upsertMessage merges a message that is already stored, keeping the media
+// state of the stored row since it may have been downloaded meanwhile.
+var upsertMessage = clause.OnConflict{
+	Columns: []clause.Column{
+		{Name: "instance_id"},
+		{Name: "chat_jid"},
+		{Name: "message_id"},
+	},
+	DoUpdates: clause.AssignmentColumns([]string{"body", "caption", "updated_at"}),
+}
+
 func (repo *messageRepository) CreateMessage(message *model.Message) error {
-	return repo.database.Client().Create(message).Error
+	return repo.database.Client().Clauses(upsertMessage).Create(message).Error
 }
 
 func (repo *messageRepository) CreateMessages(messages *[]model.Message) error {
-	return repo.database.Client().Create(messages).Error
+	// a conflict can only be resolved once per statement, so duplicates
+	// inside the batch are dropped first, keeping the last one
+	seen := map[string]int{}
+	unique := []model.Message{}
+	for _, message := range *messages {
+		key := message.InstanceID + "|" + message.ChatJID + "|" + message.MessageID
+		if i, ok := seen[key]; ok {
+			unique[i] = message
+			continue
+		}
+		seen[key] = len(unique)
+		unique = append(unique, message)
+	}
+
+	if len(unique) == 0 {
+		*messages = unique
+		return nil
+	}
+
+	// messages stored before keep their media state, which is read back
+	// so callers don't download media that was already handled
+	returning := clause.Returning{Columns: []clause.Column{
+		{Name: "id"},
+		{Name: "media_status"},
+		{Name: "media_path"},
+		{Name: "thumbnail_path"},
+	}}
+	err := repo.database.Client().Clauses(upsertMessage, returning).Create(&unique).Error
+	if err != nil {
+		return err
+	}
+	*messages = unique
+	return nil
 }
 
 func (repo *messageRepository) CountChatMessages(instanceID string, chatJID string) (int64, error) {
@@ -45,9 +152,288 @@ func (repo *messageRepository) GetChatMessages(instanceID string, chatJID string
 	return &messages, nil
 }
 
+func (repo *messageRepository) QueryChatMessages(query MessageQuery) (*[]model.Message, error) {
+	query.Since = localTime(query.Since)
+	query.Until = localTime(query.Until)
+	for _, cursor := range []*MessageCursor{query.Before, query.After} {
+		if cursor != nil && cursor.ChatJID != "" && cursor.ChatJID != query.ChatJID {
+			return nil, ErrCursorChatMismatch
+		}
+	}
+	if query.Before != nil {
+		query.Before = &MessageCursor{Timestamp: query.Before.Timestamp.Local(), ID: query.Before.ID}
+	}
+	if query.After != nil {
+		query.After = &MessageCursor{Timestamp: query.After.Timestamp.Local(), ID: query.After.ID}
+	}
+
+	tx := repo.database.Client().Where("instance_id = ? AND chat_jid = ?", query.InstanceID, query.ChatJID)
+
+	if query.SenderJID != "" {
+		tx = tx.Where("sender_jid = ?", query.SenderJID)
+	}
+	if query.FromMe != nil {
+		tx = tx.Where("from_me = ?", *query.FromMe)
+	}
+	if query.MediaType == "text" {
+		tx = tx.Where("media_type = ''")
+	} else if query.MediaType != "" {
+		tx = tx.Where("media_type = ?", query.MediaType)
+	}
+	if query.Since != nil {
+		tx = tx.Where("timestamp >= ?", *query.Since)
+	}
+	if query.Until != nil {
+		tx = tx.Where("timestamp <= ?", *query.Until)
+	}
+
+	if query.Before != nil {
+		if query.Before.ID == 0 {
+			tx = tx.Where("timestamp < ?", query.Before.Timestamp)
+		} else {
+			tx = tx.Where(
+				"(timestamp < ? OR (timestamp = ? AND id < ?))",
+				query.Before.Timestamp, query.Before.Timestamp, query.Before.ID,
+			)
+		}
+	}
+	if query.After != nil {
+		if query.After.ID == 0 {
+			tx = tx.Where("timestamp > ?", query.After.Timestamp)
+		} else {
+			tx = tx.Where(
+				"(timestamp > ? OR (timestamp = ? AND id > ?))",
+				query.After.Timestamp, query.After.Timestamp, query.After.ID,
+			)
+		}
+	}
+
+	// paging forward walks up from the cursor, so the page is read
+	// oldest first and flipped to keep the newest first order
+	ascending := query.After != nil && query.Before == nil
+	if ascending {
+		tx = tx.Order("timestamp ASC, id ASC")
+	} else {
+		tx = tx.Order("timestamp DESC, id DESC")
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var messages []model.Message
+	if result := tx.Find(&messages); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if ascending {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+	return &messages, nil
+}
+
+func (repo *messageRepository) SearchMessages(search MessageSearch) (*[]MessageMatch, error) {
+	client := repo.database.Client()
+
+	// a sqlite built without FTS5 has no index, see the add_message_search
+	// migration
+	indexed := true
+
+	var tx *gorm.DB
+	if client.Dialector.Name() == "postgres" {
+		tx = client.Table("messages").
+			Select(
+				"messages.*, ts_headline('simple', coalesce(messages.body, '') || ' ' || coalesce(messages.caption, ''), websearch_to_tsquery('simple', ?), ?) AS snippet",
+				search.Query,
+				"StartSel="+snippetStart+", StopSel="+snippetEnd+", MaxWords=24, MinWords=8",
+			).
+			Where("messages.search @@ websearch_to_tsquery('simple', ?)", search.Query).
+			Order(clause.Expr{
+				SQL:  "ts_rank(messages.search, websearch_to_tsquery('simple', ?)) DESC",
+				Vars: []interface{}{search.Query},
+			})
+	} else if client.Migrator().HasTable("messages_fts") {
+		tx = client.Table("messages_fts").
+			Select("messages.*, snippet(messages_fts, -1, ?, ?, '…', 16) AS snippet", snippetStart, snippetEnd).
+			Joins("JOIN messages ON messages.id = messages_fts.rowid").
+			Where("messages_fts MATCH ?", makeMatchQuery(search.Query)).
+			Order("rank")
+	} else {
+		indexed = false
+		tx = client.Table("messages").Select("messages.*")
+		for _, term := range strings.Fields(search.Query) {
+			pattern := "%" + likeEscaper.Replace(term) + "%"
+			tx = tx.Where(
+				`(LOWER(coalesce(messages.body, '')) LIKE LOWER(?) ESCAPE '\' OR LOWER(coalesce(messages.caption, '')) LIKE LOWER(?) ESCAPE '\')`,
+				pattern, pattern,
+			)
+		}
+		tx = tx.Order("messages.timestamp DESC, messages.id DESC")
+	}
+
+	tx = tx.Where("messages.instance_id = ? AND messages.deleted_at IS NULL", search.InstanceID)
+	if search.ChatJID != "" {
+		tx = tx.Where("messages.chat_jid = ?", search.ChatJID)
+	}
+	if search.SenderJID != "" {
+		tx = tx.Where("messages.sender_jid = ?", search.SenderJID)
+	}
+	if since := localTime(search.Since); since != nil {
+		tx = tx.Where("messages.timestamp >= ?", *since)
+	}
+	if until := localTime(search.Until); until != nil {
+		tx = tx.Where("messages.timestamp <= ?", *until)
+	}
+	if search.Limit > 0 {
+		tx = tx.Limit(search.Limit)
+	}
+	if search.Offset > 0 {
+		tx = tx.Offset(search.Offset)
+	}
+
+	var matches []MessageMatch
+	if result := tx.Scan(&matches); result.Error != nil {
+		return nil, result.Error
+	}
+	if !indexed {
+		for i := range matches {
+			matches[i].Snippet = makeSnippet(matches[i].Body+" "+matches[i].Caption, search.Query)
+		}
+	}
+	return &matches, nil
+}
+
+// makeSnippet highlights the words of text that contain a word of query, as
+// the FTS5 snippet does, keeping up to 16 words around the first one.
+func makeSnippet(text string, query string) string {
+	terms := strings.Fields(strings.ToLower(query))
+	words := strings.Fields(text)
+
+	first := -1
+	highlighted := make([]string, len(words))
+	for i, word := range words {
+		highlighted[i] = word
+		lower := strings.ToLower(word)
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				highlighted[i] = snippetStart + word + snippetEnd
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+
+	start := max(first-4, 0)
+	end := min(start+16, len(words))
+	snippet := strings.Join(highlighted[start:end], " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// makeMatchQuery quotes every word, so FTS5 operators typed by the user are
+// searched as plain text and all words must match.
+func makeMatchQuery(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
+
+// localTime converts t to local time, the zone messages are stored in, as
+// sqlite compares timestamps as text.
+func localTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.Local()
+	return &local
+}
+
+func (repo *messageRepository) GetMessage(id uint) (*model.Message, error) {
+	var message model.Message
+	result := repo.database.Client().First(&message, id)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &message, nil
+}
+
+func (repo *messageRepository) GetMessageByMessageID(instanceID string, messageID string) (*model.Message, error) {
+	var message model.Message
+	result := repo.database.Client().Where("instance_id = ? AND message_id = ?", instanceID, messageID).First(&message)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &message, nil
+}
+
+func (repo *messageRepository) GetMediaMessages(instanceID string) (*[]model.Message, error) {
+	var messages []model.Message
+	if result := repo.database.Client().Where("instance_id = ? AND media_path <> '' AND media_status <> ?", instanceID, model.MediaStatusExpired).Order("timestamp ASC").Find(&messages); result.Error != nil {
+		return nil, result.Error
+	}
+	return &messages, nil
+}
+
+func (repo *messageRepository) UpdateMessage(id uint, data map[string]interface{}) error {
+	return repo.database.Client().Model(&model.Message{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (repo *messageRepository) UpdateChatMessage(instanceID string, chatJID string, messageID string, data map[string]interface{}) error {
+	return repo.database.Client().Model(&model.Message{}).
+		Where("instance_id = ? AND chat_jid = ? AND message_id = ?", instanceID, chatJID, messageID).
+		Updates(data).Error
+}
+
 func (repo *messageRepository) DeleteMessagesByInstanceID(instanceID string) error {
 	if result := repo.database.Client().Where("instance_id = ?", instanceID).Unscoped().Delete(&model.Message{}); result.Error != nil {
 		return result.Error
 	}
 	return nil
 }
+
+// GetChatMediaFiles returns the paths of the media and thumbnails stored for
+// the chat.
+func (repo *messageRepository) GetChatMediaFiles(instanceID string, chatJID string) ([]string, error) {
+	var messages []model.Message
+	result := repo.database.Client().
+		Select("media_path", "thumbnail_path").
+		Where("instance_id = ? AND chat_jid = ? AND (media_path <> '' OR thumbnail_path <> '')", instanceID, chatJID).
+		Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var files []string
+	for _, message := range messages {
+		if message.MediaPath != "" {
+			files = append(files, message.MediaPath)
+		}
+		if message.ThumbnailPath != "" {
+			files = append(files, message.ThumbnailPath)
+		}
+	}
+	return files, nil
+}
+
+func (repo *messageRepository) DeleteChatMessages(instanceID string, chatJID string) error {
+	return repo.database.Client().
+		Where("instance_id = ? AND chat_jid = ?", instanceID, chatJID).
+		Unscoped().
+		Delete(&model.Message{}).Error
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"zapmeow/api/migration"
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+)
+
+func TestQueryChatMessagesRejectsCursorOfAnotherChat(t *testing.T) {
+	db := database.NewDatabase("sqlite://" + filepath.Join(t.TempDir(), "zapmeow.db"))
+	repo := NewMessageRepository(db)
+
+	_, err := repo.QueryChatMessages(MessageQuery{
+		InstanceID: "a",
+		ChatJID:    "5511999999999@s.whatsapp.net",
+		Before: &MessageCursor{
+			ChatJID:   "5511888888888@s.whatsapp.net",
+			Timestamp: time.Now(),
+			ID:        1,
+		},
+	})
+	if !errors.Is(err, ErrCursorChatMismatch) {
+		t.Fatalf("err = %v, want ErrCursorChatMismatch", err)
+	}
+}
+
+func TestSearchMessagesWithOrWithoutFTS5(t *testing.T) {
+	// without -tags sqlite_fts5 the migrations skip the index and the search
+	// falls back to matching the text
+	db := database.NewDatabase("sqlite://" + filepath.Join(t.TempDir(), "zapmeow.db"))
+	if err := db.MigrateUp(migration.Migrations); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewMessageRepository(db)
+
+	for i, body := range []string{"meet at the station", "ana_b sent the report", "anaxb is late"} {
+		message := model.Message{
+			InstanceID: "a",
+			ChatJID:    "5511999999999@s.whatsapp.net",
+			MessageID:  fmt.Sprint("m", i),
+			Timestamp:  time.Now(),
+			Body:       body,
+		}
+		if err := repo.CreateMessage(&message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for query, want := range map[string]string{
+		"station":    "m0",
+		"REPORT ana": "m1",
+		"late":       "m2",
+	} {
+		matches, err := repo.SearchMessages(MessageSearch{InstanceID: "a", Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(*matches) != 1 || (*matches)[0].MessageID != want {
+			t.Errorf("search %q found %v, want only %s", query, *matches, want)
+			continue
+		}
+		if !strings.Contains((*matches)[0].Snippet, snippetStart) {
+			t.Errorf("search %q has no highlight in %q", query, (*matches)[0].Snippet)
+		}
+	}
+}
+
+func TestMakeSnippetHighlightsAroundTheFirstMatch(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty"
+	want := "…two three four five <mark>Six</mark> seven eight nine ten eleven twelve thirteen fourteen fifteen <mark>sixteen</mark> seventeen…"
+	if got := makeSnippet(strings.Replace(text, "six", "Six", 1), "six"); got != want {
+		t.Errorf("makeSnippet = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StatusRepository interface {
+	CreateStatus(status *model.Status) error
+	GetStatus(id uint) (*model.Status, error)
+	GetStatusByStatusID(instanceID string, senderJID string, statusID string) (*model.Status, error)
+	GetStatuses(query StatusQuery) (*[]model.Status, int64, error)
+	UpdateStatus(id uint, data map[string]interface{}) error
+	DeleteStatusesByInstanceID(instanceID string) error
+}
+
+// StatusQuery selects a page of status updates, newest first.
+type StatusQuery struct {
+	InstanceID string
+	SenderJID  string
+	FromMe     *bool
+	Since      *time.Time
+	Limit      int
+	Offset     int
+}
+
+type statusRepository struct {
+	database database.Database
+}
+
+func NewStatusRepository(database database.Database) *statusRepository {
+	return &statusRepository{database: database}
+}
+
+// CreateStatus leaves a status that is already stored untouched, as
+// redelivered updates carry nothing new.
+func (repo *statusRepository) CreateStatus(status *model.Status) error {
+	return repo.database.Client().Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "instance_id"},
+			{Name: "sender_jid"},
+			{Name: "status_id"},
+		},
+		DoNothing: true,
+	}).Create(status).Error
+}
+
+func (repo *statusRepository) GetStatus(id uint) (*model.Status, error) {
+	var status model.Status
+	result := repo.database.Client().First(&status, id)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &status, nil
+}
+
+func (repo *statusRepository) GetStatusByStatusID(instanceID string, senderJID string, statusID string) (*model.Status, error) {
+	var status model.Status
+	result := repo.database.Client().
+		Where("instance_id = ? AND sender_jid = ? AND status_id = ?", instanceID, senderJID, statusID).
+		First(&status)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+		return nil, nil
+	}
+	return &status, nil
+}
+
+func (repo *statusRepository) GetStatuses(query StatusQuery) (*[]model.Status, int64, error) {
+	tx := repo.database.Client().Model(&model.Status{}).Where("instance_id = ?", query.InstanceID)
+	if query.SenderJID != "" {
+		tx = tx.Where("sender_jid = ?", query.SenderJID)
+	}
+	if query.FromMe != nil {
+		tx = tx.Where("from_me = ?", *query.FromMe)
+	}
+	if query.Since != nil {
+		tx = tx.Where("timestamp >= ?", *localTime(query.Since))
+	}
+
+	var total int64
+	if result := tx.Count(&total); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	tx = tx.Order("timestamp DESC, id DESC")
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	var statuses []model.Status
+	if result := tx.Find(&statuses); result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return &statuses, total, nil
+}
+
+func (repo *statusRepository) UpdateStatus(id uint, data map[string]interface{}) error {
+	return repo.database.Client().Model(&model.Status{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (repo *statusRepository) DeleteStatusesByInstanceID(instanceID string) error {
+	return repo.database.Client().Where("instance_id = ?", instanceID).Unscoped().Delete(&model.Status{}).Error
+}
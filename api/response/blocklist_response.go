@@ -0,0 +1,24 @@
+package response
+
+import "zapmeow/pkg/whatsapp"
+
+type BlockedContact struct {
+	Phone string `json:"phone"`
+	JID   string `json:"jid"`
+}
+
+func NewBlockedContactResponse(jid whatsapp.JID) BlockedContact {
+	return BlockedContact{
+		Phone: jid.User,
+		JID:   jid.String(),
+	}
+}
+
+func NewBlocklistResponse(jids []whatsapp.JID) []BlockedContact {
+	data := []BlockedContact{}
+	for _, jid := range jids {
+		data = append(data, NewBlockedContactResponse(jid))
+	}
+
+	return data
+}
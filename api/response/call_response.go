@@ -0,0 +1,29 @@
+package response
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+type Call struct {
+	ID        string    `json:"id"`
+	Phone     string    `json:"phone"`
+	JID       string    `json:"jid"`
+	Group     string    `json:"group,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewCallResponse describes the call from the side of whoever started it.
+func NewCallResponse(meta types.BasicCallMeta) Call {
+	call := Call{
+		ID:        meta.CallID,
+		Phone:     meta.CallCreator.User,
+		JID:       meta.CallCreator.ToNonAD().String(),
+		Timestamp: meta.Timestamp,
+	}
+	if !meta.GroupJID.IsEmpty() {
+		call.Group = meta.GroupJID.String()
+	}
+	return call
+}
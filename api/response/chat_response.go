@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+	"zapmeow/api/model"
+)
+
+type Chat struct {
+	Chat          string     `json:"chat"`
+	JID           string     `json:"jid"`
+	Name          string     `json:"name"`
+	UnreadCount   int        `json:"unread_count"`
+	MarkedUnread  bool       `json:"marked_unread"`
+	Archived      bool       `json:"archived"`
+	Pinned        bool       `json:"pinned"`
+	MutedUntil    *time.Time `json:"muted_until"`
+	LastMessageAt *time.Time `json:"last_message_at"`
+}
+
+func NewChatResponse(chat model.Chat) Chat {
+	return Chat{
+		Chat:          chat.ChatJID,
+		JID:           chat.RemoteJID,
+		Name:          chat.Name,
+		UnreadCount:   chat.UnreadCount,
+		MarkedUnread:  chat.MarkedUnread,
+		Archived:      chat.Archived,
+		Pinned:        chat.Pinned,
+		MutedUntil:    chat.MutedUntil,
+		LastMessageAt: chat.LastMessageAt,
+	}
+}
+
+func NewChatsResponse(chats *[]model.Chat) []Chat {
+	data := []Chat{}
+	for _, chat := range *chats {
+		data = append(data, NewChatResponse(chat))
+	}
+
+	return data
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"encoding/base64"
+	"os"
+	"time"
+	"zapmeow/api/model"
+)
+
+type Contact struct {
+	Phone            string     `json:"phone"`
+	JID              string     `json:"jid"`
+	PushName         string     `json:"push_name"`
+	BusinessName     string     `json:"business_name"`
+	FullName         string     `json:"full_name"`
+	FirstName        string     `json:"first_name"`
+	Status           string     `json:"status"`
+	PictureID        string     `json:"picture_id"`
+	PictureURL       string     `json:"picture_url"`
+	PictureUpdatedAt *time.Time `json:"picture_updated_at"`
+}
+
+func NewContactResponse(contact model.Contact) Contact {
+	return Contact{
+		Phone:            contact.ContactJID,
+		JID:              contact.RemoteJID,
+		PushName:         contact.PushName,
+		BusinessName:     contact.BusinessName,
+		FullName:         contact.FullName,
+		FirstName:        contact.FirstName,
+		Status:           contact.Status,
+		PictureID:        contact.PictureID,
+		PictureURL:       contact.PictureURL,
+		PictureUpdatedAt: contact.PictureUpdatedAt,
+	}
+}
+
+func NewContactsResponse(contacts *[]model.Contact) []Contact {
+	data := []Contact{}
+	for _, contact := range *contacts {
+		data = append(data, NewContactResponse(contact))
+	}
+
+	return data
+}
+
+type ContactInfo struct {
+	Contact
+	PictureBase64 string `json:"picture_base64,omitempty"`
+}
+
+// NewContactInfoResponse inlines the downloaded profile picture, if any.
+func NewContactInfoResponse(contact model.Contact) ContactInfo {
+	data := ContactInfo{Contact: NewContactResponse(contact)}
+	if contact.PicturePath != "" {
+		if picture, err := os.ReadFile(contact.PicturePath); err == nil {
+			data.PictureBase64 = base64.StdEncoding.EncodeToString(picture)
+		}
+	}
+	return data
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"time"
+	"zapmeow/api/model"
+)
+
+type Instance struct {
+	InstanceID        string    `json:"instance_id"`
+	Status            string    `json:"status"`
+	Phone             string    `json:"phone"`
+	Connected         bool      `json:"connected"`
+	Node              string    `json:"node,omitempty"`
+	DisconnectReason  string    `json:"disconnect_reason,omitempty"`
+	ReconnectAttempts int       `json:"reconnect_attempts"`
+	CustomerName      string    `json:"customer_name"`
+	Tags              []string  `json:"tags"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func NewInstanceResponse(account model.Account, connected bool) Instance {
+	tags := account.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return Instance{
+		InstanceID:        account.InstanceID,
+		Status:            account.Status,
+		Phone:             account.User,
+		Connected:         connected,
+		DisconnectReason:  account.DisconnectReason,
+		ReconnectAttempts: account.ReconnectAttempts,
+		CustomerName:      account.CustomerName,
+		Tags:              tags,
+		CreatedAt:         account.CreatedAt,
+	}
+}
+
+type InstanceSettings struct {
+	CustomerName      string   `json:"customer_name"`
+	Tags              []string `json:"tags"`
+	WebhookURL        *string  `json:"webhook_url"`
+	HistorySync       *bool    `json:"history_sync"`
+	MaxMessageSync    *int     `json:"max_message_sync"`
+	AutoRead          *bool    `json:"auto_read"`
+	RejectCalls       *bool    `json:"reject_calls"`
+	RejectCallMessage *string  `json:"reject_call_message"`
+}
+
+func NewInstanceSettingsResponse(account model.Account) InstanceSettings {
+	tags := account.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return InstanceSettings{
+		CustomerName:      account.CustomerName,
+		Tags:              tags,
+		WebhookURL:        account.Settings.WebhookURL,
+		HistorySync:       account.Settings.HistorySync,
+		MaxMessageSync:    account.Settings.MaxMessageSync,
+		AutoRead:          account.Settings.AutoRead,
+		RejectCalls:       account.Settings.RejectCalls,
+		RejectCallMessage: account.Settings.RejectCallMessage,
+	}
+}
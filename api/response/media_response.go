@@ -0,0 +1,27 @@
+package response
+
+import "zapmeow/api/model"
+
+type MediaRetentionPolicy struct {
+	MediaType     string `json:"media_type"`
+	MaxAgeDays    int    `json:"max_age_days"`
+	MaxBytes      int64  `json:"max_bytes"`
+	KeepThumbnail bool   `json:"keep_thumbnail"`
+}
+
+func NewMediaRetentionPolicyResponse(policy model.MediaRetentionPolicy) MediaRetentionPolicy {
+	return MediaRetentionPolicy{
+		MediaType:     policy.MediaType,
+		MaxAgeDays:    policy.MaxAgeDays,
+		MaxBytes:      policy.MaxBytes,
+		KeepThumbnail: policy.KeepThumbnail,
+	}
+}
+
+func NewMediaRetentionPoliciesResponse(policies []model.MediaRetentionPolicy) []MediaRetentionPolicy {
+	data := []MediaRetentionPolicy{}
+	for _, policy := range policies {
+		data = append(data, NewMediaRetentionPolicyResponse(policy))
+	}
+	return data
+}
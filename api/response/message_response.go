@@ -10,31 +10,45 @@ import (
 )
 
 type Message struct {
-	ID            uint      `json:"id"`
-	Sender        string    `json:"sender"`
-	Chat          string    `json:"chat"`
-	MessageID     string    `json:"message_id"`
-	FromMe        bool      `json:"from_me"`
-	Timestamp     time.Time `json:"timestamp"`
-	Body          string    `json:"body"`
-	MediaType     string    `json:"media_type"`
-	MediaMimeType string    `json:"media_mimetype"`
-	MediaBase64   string    `json:"media_base64"`
+	ID              uint       `json:"id"`
+	Sender          string     `json:"sender"`
+	Chat            string     `json:"chat"`
+	MessageID       string     `json:"message_id"`
+	FromMe          bool       `json:"from_me"`
+	Timestamp       time.Time  `json:"timestamp"`
+	Body            string     `json:"body"`
+	Caption         string     `json:"caption,omitempty"`
+	MediaType       string     `json:"media_type"`
+	MediaStatus     string     `json:"media_status"`
+	MediaMimeType   string     `json:"media_mimetype"`
+	MediaBase64     string     `json:"media_base64"`
+	ThumbnailBase64 string     `json:"thumbnail_base64,omitempty"`
+	EditedAt        *time.Time `json:"edited_at,omitempty"`
+	Revoked         bool       `json:"revoked"`
 }
 
 func NewMessageResponse(msg model.Message) Message {
 	data := Message{
-		ID:        msg.ID,
-		Sender:    msg.SenderJID,
-		Chat:      msg.ChatJID,
-		MessageID: msg.MessageID,
-		FromMe:    msg.FromMe,
-		Timestamp: msg.Timestamp,
-		Body:      msg.Body,
-		MediaType: msg.MediaType,
+		ID:          msg.ID,
+		Sender:      msg.SenderJID,
+		Chat:        msg.ChatJID,
+		MessageID:   msg.MessageID,
+		FromMe:      msg.FromMe,
+		Timestamp:   msg.Timestamp,
+		Body:        msg.Body,
+		Caption:     msg.Caption,
+		MediaType:   msg.MediaType,
+		MediaStatus: msg.MediaStatus,
+		EditedAt:    msg.EditedAt,
+		Revoked:     msg.Revoked,
 	}
 
-	if msg.MediaType != "" {
+	if msg.MediaStatus == model.MediaStatusExpired {
+		// only the thumbnail survives expired media, when the policy keeps it
+		if thumbnail, err := os.ReadFile(msg.ThumbnailPath); err == nil {
+			data.ThumbnailBase64 = base64.StdEncoding.EncodeToString(thumbnail)
+		}
+	} else if msg.MediaType != "" {
 		media, err := os.ReadFile(msg.MediaPath)
 		if err != nil {
 			// logger.Error("Error reading the file. ", err)
@@ -49,11 +63,54 @@ func NewMessageResponse(msg model.Message) Message {
 	return data
 }
 
+// NewMessageMetadataResponse leaves the media out, so no file is read.
+func NewMessageMetadataResponse(msg model.Message) Message {
+	data := Message{
+		ID:          msg.ID,
+		Sender:      msg.SenderJID,
+		Chat:        msg.ChatJID,
+		MessageID:   msg.MessageID,
+		FromMe:      msg.FromMe,
+		Timestamp:   msg.Timestamp,
+		Body:        msg.Body,
+		Caption:     msg.Caption,
+		MediaType:   msg.MediaType,
+		MediaStatus: msg.MediaStatus,
+		EditedAt:    msg.EditedAt,
+		Revoked:     msg.Revoked,
+	}
+	if msg.MediaPath != "" {
+		data.MediaMimeType = mime.TypeByExtension(filepath.Ext(msg.MediaPath))
+	}
+	return data
+}
+
+func NewMessagesMetadataResponse(msgs *[]model.Message) []Message {
+	data := []Message{}
+	for _, message := range *msgs {
+		data = append(data, NewMessageMetadataResponse(message))
+	}
+
+	return data
+}
+
 func NewMessagesResponse(msgs *[]model.Message) []Message {
-	var data []Message
+	data := make([]Message, 0, len(*msgs))
 	for _, message := range *msgs {
 		data = append(data, NewMessageResponse(message))
 	}
 
 	return data
 }
+
+type MessageMatch struct {
+	Message Message `json:"message"`
+	Snippet string  `json:"snippet"`
+}
+
+func NewMessageMatchResponse(msg model.Message, snippet string) MessageMatch {
+	return MessageMatch{
+		Message: NewMessageMetadataResponse(msg),
+		Snippet: snippet,
+	}
+}
@@ -0,0 +1,81 @@
+package response
+
+import (
+	"time"
+	"zapmeow/pkg/whatsapp"
+)
+
+type Newsletter struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Invite      string    `json:"invite"`
+	Subscribers int       `json:"subscribers"`
+	Verified    bool      `json:"verified"`
+	State       string    `json:"state"`
+	Role        string    `json:"role,omitempty"`
+	Muted       bool      `json:"muted"`
+	PictureURL  string    `json:"picture_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewNewsletterResponse(newsletter whatsapp.Newsletter) Newsletter {
+	return Newsletter{
+		ID:          newsletter.JID.String(),
+		Name:        newsletter.Name,
+		Description: newsletter.Description,
+		Invite:      newsletter.InviteCode,
+		Subscribers: newsletter.SubscriberCount,
+		Verified:    newsletter.Verified,
+		State:       newsletter.State,
+		Role:        newsletter.Role,
+		Muted:       newsletter.Muted,
+		PictureURL:  newsletter.PictureURL,
+		CreatedAt:   newsletter.CreatedAt,
+	}
+}
+
+func NewNewslettersResponse(newsletters []whatsapp.Newsletter) []Newsletter {
+	data := []Newsletter{}
+	for _, newsletter := range newsletters {
+		data = append(data, NewNewsletterResponse(newsletter))
+	}
+
+	return data
+}
+
+type NewsletterMessage struct {
+	ServerID  int            `json:"server_id"`
+	MessageID string         `json:"message_id"`
+	Timestamp time.Time      `json:"timestamp"`
+	Views     int            `json:"views"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+	Body      string         `json:"body"`
+	Caption   string         `json:"caption,omitempty"`
+	MediaType string         `json:"media_type"`
+}
+
+func NewNewsletterMessageResponse(message whatsapp.NewsletterMessage) NewsletterMessage {
+	data := NewsletterMessage{
+		ServerID:  message.ServerID,
+		MessageID: message.MessageID,
+		Timestamp: message.Timestamp,
+		Views:     message.Views,
+		Reactions: message.Reactions,
+		Body:      message.Body,
+		Caption:   message.Caption,
+	}
+	if message.MediaType != nil {
+		data.MediaType = message.MediaType.String()
+	}
+	return data
+}
+
+func NewNewsletterMessagesResponse(messages []whatsapp.NewsletterMessage) []NewsletterMessage {
+	data := []NewsletterMessage{}
+	for _, message := range messages {
+		data = append(data, NewNewsletterMessageResponse(message))
+	}
+
+	return data
+}
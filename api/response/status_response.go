@@ -0,0 +1,64 @@
+package response
+
+import (
+	"encoding/base64"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+	"zapmeow/api/model"
+)
+
+type Status struct {
+	ID              uint      `json:"id"`
+	StatusID        string    `json:"status_id"`
+	Sender          string    `json:"sender"`
+	FromMe          bool      `json:"from_me"`
+	Timestamp       time.Time `json:"timestamp"`
+	Body            string    `json:"body"`
+	Caption         string    `json:"caption,omitempty"`
+	MediaType       string    `json:"media_type"`
+	MediaStatus     string    `json:"media_status"`
+	MediaMimeType   string    `json:"media_mimetype"`
+	MediaBase64     string    `json:"media_base64"`
+	ThumbnailBase64 string    `json:"thumbnail_base64,omitempty"`
+	Revoked         bool      `json:"revoked"`
+}
+
+func NewStatusResponse(status model.Status) Status {
+	data := Status{
+		ID:          status.ID,
+		StatusID:    status.StatusID,
+		Sender:      status.SenderJID,
+		FromMe:      status.FromMe,
+		Timestamp:   status.Timestamp,
+		Body:        status.Body,
+		Caption:     status.Caption,
+		MediaType:   status.MediaType,
+		MediaStatus: status.MediaStatus,
+		Revoked:     status.Revoked,
+	}
+
+	if status.MediaPath != "" {
+		if media, err := os.ReadFile(status.MediaPath); err == nil {
+			data.MediaMimeType = mime.TypeByExtension(filepath.Ext(status.MediaPath))
+			data.MediaBase64 = base64.StdEncoding.EncodeToString(media)
+		}
+	} else if status.ThumbnailPath != "" {
+		// the thumbnail stands in until the media is downloaded
+		if thumbnail, err := os.ReadFile(status.ThumbnailPath); err == nil {
+			data.ThumbnailBase64 = base64.StdEncoding.EncodeToString(thumbnail)
+		}
+	}
+
+	return data
+}
+
+func NewStatusesResponse(statuses *[]model.Status) []Status {
+	data := []Status{}
+	for _, status := range *statuses {
+		data = append(data, NewStatusResponse(status))
+	}
+
+	return data
+}
@@ -2,6 +2,8 @@ package route
 
 import (
 	"zapmeow/api/handler"
+	"zapmeow/api/helper"
+	"zapmeow/api/middleware"
 	"zapmeow/api/service"
 	"zapmeow/config"
 	"zapmeow/pkg/zapmeow"
@@ -23,15 +25,54 @@ func SetupRouter(
 	whatsAppService service.WhatsAppService,
 	messageService service.MessageService,
 	accountService service.AccountService,
+	mediaService service.MediaService,
+	settingsService service.SettingsService,
+	clusterService service.ClusterService,
+	chatService service.ChatService,
+	contactService service.ContactService,
+	statusService service.StatusService,
 ) *gin.Engine {
 	router := makeEngine(app.Config)
 
+	createInstanceHandler := handler.NewCreateInstanceHandler(
+		whatsAppService,
+		accountService,
+	)
+	listInstancesHandler := handler.NewListInstancesHandler(
+		app,
+		whatsAppService,
+		accountService,
+		clusterService,
+	)
+	deleteInstanceHandler := handler.NewDeleteInstanceHandler(
+		whatsAppService,
+		mediaService,
+	)
+	restartInstanceHandler := handler.NewRestartInstanceHandler(
+		whatsAppService,
+	)
+	getSettingsHandler := handler.NewGetSettingsHandler(
+		accountService,
+		settingsService,
+	)
+	updateSettingsHandler := handler.NewUpdateSettingsHandler(
+		accountService,
+		settingsService,
+	)
 	getQrCodeHandler := handler.NewGetQrCodeHandler(
 		app,
 		whatsAppService,
 		messageService,
 		accountService,
 	)
+	streamQrCodeHandler := handler.NewStreamQrCodeHandler(
+		app,
+		whatsAppService,
+		accountService,
+	)
+	pairPhoneHandler := handler.NewPairPhoneHandler(
+		whatsAppService,
+	)
 	logoutHandler := handler.NewLogoutHandler(
 		app,
 		whatsAppService,
@@ -45,6 +86,22 @@ func SetupRouter(
 	getProfileInfoHandler := handler.NewGetProfileInfoHandler(
 		whatsAppService,
 	)
+	setProfileNameHandler := handler.NewSetProfileNameHandler(
+		whatsAppService,
+	)
+	setProfileAboutHandler := handler.NewSetProfileAboutHandler(
+		whatsAppService,
+	)
+	setProfilePictureHandler := handler.NewSetProfilePictureHandler(
+		whatsAppService,
+		mediaService,
+	)
+	getPrivacySettingsHandler := handler.NewGetPrivacySettingsHandler(
+		whatsAppService,
+	)
+	setPrivacySettingsHandler := handler.NewSetPrivacySettingsHandler(
+		whatsAppService,
+	)
 	getContactInfoHandler := handler.NewGetContactInfoHandler(
 		whatsAppService,
 	)
@@ -55,6 +112,50 @@ func SetupRouter(
 		whatsAppService,
 		messageService,
 	)
+	getContactsHandler := handler.NewGetContactsHandler(
+		accountService,
+		contactService,
+	)
+	getContactsInfoHandler := handler.NewGetContactsInfoHandler(
+		whatsAppService,
+	)
+	getBlocklistHandler := handler.NewGetBlocklistHandler(
+		whatsAppService,
+	)
+	blockContactHandler := handler.NewBlockContactHandler(
+		whatsAppService,
+	)
+	getChatsHandler := handler.NewGetChatsHandler(
+		accountService,
+		chatService,
+	)
+	archiveChatHandler := handler.NewArchiveChatHandler(
+		whatsAppService,
+		chatService,
+	)
+	pinChatHandler := handler.NewPinChatHandler(
+		whatsAppService,
+		chatService,
+	)
+	muteChatHandler := handler.NewMuteChatHandler(
+		whatsAppService,
+		chatService,
+	)
+	markChatReadHandler := handler.NewMarkChatReadHandler(
+		whatsAppService,
+		chatService,
+	)
+	clearChatHandler := handler.NewClearChatHandler(
+		whatsAppService,
+		chatService,
+	)
+	deleteChatHandler := handler.NewDeleteChatHandler(
+		whatsAppService,
+	)
+	searchMessagesHandler := handler.NewSearchMessagesHandler(
+		accountService,
+		messageService,
+	)
 	sendTextMessageHandler := handler.NewSendTextMessageHandler(
 		whatsAppService,
 		messageService,
@@ -62,30 +163,150 @@ func SetupRouter(
 	sendImageMessageHandler := handler.NewSendImageMessageHandler(
 		whatsAppService,
 		messageService,
+		mediaService,
 	)
 	sendAudioMessageHandler := handler.NewSendAudioMessageHandler(
 		whatsAppService,
 		messageService,
+		mediaService,
 	)
 	sendDocumentMessageHandler := handler.NewSendDocumentMessageHandler(
 		whatsAppService,
 		messageService,
+		mediaService,
+	)
+	downloadMediaHandler := handler.NewDownloadMediaHandler(
+		whatsAppService,
+		messageService,
+	)
+	retryMediaHandler := handler.NewRetryMediaHandler(
+		whatsAppService,
+		messageService,
+	)
+	sendTextStatusHandler := handler.NewSendTextStatusHandler(
+		whatsAppService,
+		statusService,
+	)
+	sendImageStatusHandler := handler.NewSendImageStatusHandler(
+		whatsAppService,
+		statusService,
+		mediaService,
+	)
+	sendVideoStatusHandler := handler.NewSendVideoStatusHandler(
+		whatsAppService,
+		statusService,
+		mediaService,
+	)
+	getStatusesHandler := handler.NewGetStatusesHandler(
+		accountService,
+		statusService,
+	)
+	getStatusAudienceHandler := handler.NewGetStatusAudienceHandler(
+		whatsAppService,
+	)
+	createNewsletterHandler := handler.NewCreateNewsletterHandler(
+		whatsAppService,
+		mediaService,
+	)
+	getNewslettersHandler := handler.NewGetNewslettersHandler(
+		whatsAppService,
+	)
+	getNewsletterInfoHandler := handler.NewGetNewsletterInfoHandler(
+		whatsAppService,
+	)
+	followNewsletterHandler := handler.NewFollowNewsletterHandler(
+		whatsAppService,
+	)
+	getNewsletterMessagesHandler := handler.NewGetNewsletterMessagesHandler(
+		whatsAppService,
+	)
+	sendNewsletterTextHandler := handler.NewSendNewsletterTextHandler(
+		whatsAppService,
+	)
+	sendNewsletterImageHandler := handler.NewSendNewsletterImageHandler(
+		whatsAppService,
+		mediaService,
+	)
+	getMediaRetentionHandler := handler.NewGetMediaRetentionHandler(
+		accountService,
+		mediaService,
+	)
+	updateMediaRetentionHandler := handler.NewUpdateMediaRetentionHandler(
+		accountService,
+		mediaService,
 	)
 
 	group := router.Group("/api")
 
-	group.GET("/:instanceId/qrcode", getQrCodeHandler.Handler)
-	group.GET("/:instanceId/status", getStatusHandler.Handler)
-	group.GET("/:instanceId/profile", getProfileInfoHandler.Handler)
-	group.GET("/:instanceId/contact/info", getContactInfoHandler.Handler)
-	group.POST("/:instanceId/logout", logoutHandler.Handler)
-	group.POST("/:instanceId/check/phones", checkPhonesHandler.Handler)
-	group.POST("/:instanceId/chat/messages", getMessagesHandler.Handler)
-	group.POST("/:instanceId/chat/send/text", sendTextMessageHandler.Handler)
-	group.POST("/:instanceId/chat/send/image", sendImageMessageHandler.Handler)
-	group.POST("/:instanceId/chat/send/audio", sendAudioMessageHandler.Handler)
-	group.POST("/:instanceId/chat/send/document", sendDocumentMessageHandler.Handler)
+	routeInstance := middleware.RouteInstance(clusterService, app.Config.ClusterForward)
+
+	group.POST("/instances", createInstanceHandler.Handler)
+	group.GET("/instances", listInstancesHandler.Handler)
+	group.DELETE("/instances/:instanceId", routeInstance, deleteInstanceHandler.Handler)
+	group.POST("/instances/:instanceId/restart", routeInstance, restartInstanceHandler.Handler)
+
+	instanceGroup := group.Group("")
+	if app.Config.StrictInstances {
+		instanceGroup.Use(middleware.RequireInstance(accountService))
+	}
+	instanceGroup.Use(routeInstance)
+	instanceGroup.GET("/:instanceId/settings", getSettingsHandler.Handler)
+	instanceGroup.PUT("/:instanceId/settings", updateSettingsHandler.Handler)
+	instanceGroup.GET("/:instanceId/qrcode", getQrCodeHandler.Handler)
+	instanceGroup.GET("/:instanceId/qrcode/stream", streamQrCodeHandler.Handler)
+	instanceGroup.POST("/:instanceId/pair", pairPhoneHandler.Handler)
+	instanceGroup.GET("/:instanceId/status", getStatusHandler.Handler)
+	instanceGroup.GET("/:instanceId/profile", getProfileInfoHandler.Handler)
+	instanceGroup.POST("/:instanceId/profile/name", setProfileNameHandler.Handler)
+	instanceGroup.POST("/:instanceId/profile/about", setProfileAboutHandler.Handler)
+	instanceGroup.POST("/:instanceId/profile/picture", setProfilePictureHandler.Handler)
+	instanceGroup.GET("/:instanceId/profile/privacy", getPrivacySettingsHandler.Handler)
+	instanceGroup.POST("/:instanceId/profile/privacy", setPrivacySettingsHandler.Handler)
+	instanceGroup.GET("/:instanceId/contacts", getContactsHandler.Handler)
+	instanceGroup.POST("/:instanceId/contacts/info", getContactsInfoHandler.Handler)
+	instanceGroup.GET("/:instanceId/contact/info", getContactInfoHandler.Handler)
+	instanceGroup.POST("/:instanceId/contact/block", blockContactHandler.Handler)
+	instanceGroup.GET("/:instanceId/blocklist", getBlocklistHandler.Handler)
+	instanceGroup.POST("/:instanceId/logout", logoutHandler.Handler)
+	instanceGroup.POST("/:instanceId/check/phones", checkPhonesHandler.Handler)
+	instanceGroup.GET("/:instanceId/chats", getChatsHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/archive", archiveChatHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/pin", pinChatHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/mute", muteChatHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/read", markChatReadHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/clear", clearChatHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/delete", deleteChatHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/messages", getMessagesHandler.Handler)
+	instanceGroup.GET("/:instanceId/messages/search", searchMessagesHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/send/text", sendTextMessageHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/send/image", sendImageMessageHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/send/audio", sendAudioMessageHandler.Handler)
+	instanceGroup.POST("/:instanceId/chat/send/document", sendDocumentMessageHandler.Handler)
+	instanceGroup.POST("/:instanceId/status/text", sendTextStatusHandler.Handler)
+	instanceGroup.POST("/:instanceId/status/image", sendImageStatusHandler.Handler)
+	instanceGroup.POST("/:instanceId/status/video", sendVideoStatusHandler.Handler)
+	instanceGroup.GET("/:instanceId/status/audience", getStatusAudienceHandler.Handler)
+	instanceGroup.GET("/:instanceId/statuses", getStatusesHandler.Handler)
+	instanceGroup.POST("/:instanceId/newsletters", createNewsletterHandler.Handler)
+	instanceGroup.GET("/:instanceId/newsletters", getNewslettersHandler.Handler)
+	instanceGroup.GET("/:instanceId/newsletter/info", getNewsletterInfoHandler.Handler)
+	instanceGroup.POST("/:instanceId/newsletter/follow", followNewsletterHandler.Handler)
+	instanceGroup.GET("/:instanceId/newsletter/messages", getNewsletterMessagesHandler.Handler)
+	instanceGroup.POST("/:instanceId/newsletter/send/text", sendNewsletterTextHandler.Handler)
+	instanceGroup.POST("/:instanceId/newsletter/send/image", sendNewsletterImageHandler.Handler)
+	instanceGroup.POST("/:instanceId/media/download", downloadMediaHandler.Handler)
+	instanceGroup.POST("/:instanceId/media/retry", retryMediaHandler.Handler)
+	instanceGroup.GET("/:instanceId/media/retention", getMediaRetentionHandler.Handler)
+	instanceGroup.PUT("/:instanceId/media/retention", updateMediaRetentionHandler.Handler)
+
 	group.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
+	// instance IDs can't take the place of the static routes
+	var paths []string
+	for _, route := range router.Routes() {
+		paths = append(paths, route.Path)
+	}
+	helper.ReserveRouteSegments(paths, group.BasePath())
+
 	return router
 }
@@ -0,0 +1,34 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"zapmeow/config"
+	"zapmeow/pkg/zapmeow"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCreateInstanceRejectsIDsShadowedByRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	app := zapmeow.NewZapMeow(nil, nil, config.Config{}, &sync.Map{}, &sync.WaitGroup{}, &sync.Mutex{}, nil)
+	router := SetupRouter(app, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	for _, id := range []string{"instances", "swagger"} {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(
+			http.MethodPost,
+			"/api/instances",
+			strings.NewReader(`{"instance_id": "`+id+`"}`),
+		)
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("instance id %q: got status %d, want %d", id, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -10,21 +10,36 @@ import (
 
 type AccountService interface {
 	CreateAccount(account *model.Account) error
+	GetAccounts() ([]model.Account, error)
 	GetConnectedAccounts() ([]model.Account, error)
 	GetAccountByInstanceID(instanceID string) (*model.Account, error)
 	UpdateAccount(instanceID string, data map[string]interface{}) error
 	DeleteAccountMessages(instanceID string) error
+	UpdateAccountSettings(account *model.Account) error
+	DeleteAccount(instanceID string) error
 }
 
 type accountService struct {
 	accountRepo    repository.AccountRepository
 	messageService MessageService
+	chatService    ChatService
+	contactService ContactService
+	statusService  StatusService
 }
 
-func NewAccountService(accountRepo repository.AccountRepository, messageService MessageService) *accountService {
+func NewAccountService(
+	accountRepo repository.AccountRepository,
+	messageService MessageService,
+	chatService ChatService,
+	contactService ContactService,
+	statusService StatusService,
+) *accountService {
 	return &accountService{
 		accountRepo:    accountRepo,
 		messageService: messageService,
+		chatService:    chatService,
+		contactService: contactService,
+		statusService:  statusService,
 	}
 }
 
@@ -32,6 +47,10 @@ func (a *accountService) CreateAccount(account *model.Account) error {
 	return a.accountRepo.CreateAccount(account)
 }
 
+func (a *accountService) GetAccounts() ([]model.Account, error) {
+	return a.accountRepo.GetAccounts()
+}
+
 func (a *accountService) GetConnectedAccounts() ([]model.Account, error) {
 	return a.accountRepo.GetConnectedAccounts()
 }
@@ -49,9 +68,48 @@ func (a *accountService) DeleteAccountMessages(instanceID string) error {
 	if err != nil {
 		return err
 	}
+
+	err = a.statusService.DeleteStatusesByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
 	return a.deleteAccountDirectory(instanceID)
 }
 
+func (a *accountService) UpdateAccountSettings(account *model.Account) error {
+	return a.accountRepo.UpdateAccountSettings(account)
+}
+
+// DeleteAccount wipes the account along with its messages, chats, contacts,
+// statuses and stored media.
+func (a *accountService) DeleteAccount(instanceID string) error {
+	err := a.messageService.DeleteMessagesByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	err = a.chatService.DeleteChatsByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	err = a.contactService.DeleteContactsByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	err = a.statusService.DeleteStatusesByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(helper.MakeAccountStoragePath(instanceID))
+	if err != nil {
+		return err
+	}
+	return a.accountRepo.DeleteAccount(instanceID)
+}
+
 func (a *accountService) deleteAccountDirectory(instanceID string) error {
 	dirPath := helper.MakeAccountStoragePath(instanceID)
 	_, err := os.Stat(dirPath)
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
+	"zapmeow/pkg/whatsapp"
+)
+
+type ChatService interface {
+	UpdateChatFromMessage(message model.Message) error
+	UpsertChat(chat *model.Chat) error
+	UpdateChat(instanceID string, chatJID string, data map[string]interface{}) error
+	MarkChatRead(instanceID string, chatJID string) error
+	SetChatArchived(instanceID string, jid whatsapp.JID, archived bool) error
+	SetChatPinned(instanceID string, jid whatsapp.JID, pinned bool) error
+	SetChatMutedUntil(instanceID string, jid whatsapp.JID, mutedUntil *time.Time) error
+	SetChatRead(instanceID string, jid whatsapp.JID, read bool) error
+	GetChat(instanceID string, chatJID string) (*model.Chat, error)
+	GetChats(query repository.ChatQuery) (*[]model.Chat, int64, error)
+	DeleteChat(instanceID string, chatJID string) error
+	DeleteChatsByInstanceID(instanceID string) error
+}
+
+type chatService struct {
+	chatRepo repository.ChatRepository
+}
+
+func NewChatService(chatRepo repository.ChatRepository) *chatService {
+	return &chatService{
+		chatRepo: chatRepo,
+	}
+}
+
+// UpdateChatFromMessage moves the chat to the time of the message. Incoming
+// messages count as unread, while sending one means the chat was read.
+func (c *chatService) UpdateChatFromMessage(message model.Message) error {
+	timestamp := message.Timestamp
+	return c.chatRepo.TouchChat(&model.Chat{
+		InstanceID:    message.InstanceID,
+		ChatJID:       message.ChatJID,
+		RemoteJID:     message.RemoteJID,
+		LastMessageAt: &timestamp,
+	}, message.FromMe)
+}
+
+func (c *chatService) UpsertChat(chat *model.Chat) error {
+	return c.chatRepo.UpsertChat(chat)
+}
+
+func (c *chatService) UpdateChat(instanceID string, chatJID string, data map[string]interface{}) error {
+	return c.chatRepo.UpdateChat(instanceID, chatJID, data)
+}
+
+func (c *chatService) MarkChatRead(instanceID string, chatJID string) error {
+	return c.chatRepo.UpdateChat(instanceID, chatJID, map[string]interface{}{
+		"UnreadCount":  0,
+		"MarkedUnread": false,
+	})
+}
+
+// SetChatArchived also unpins archived chats, as WhatsApp does.
+func (c *chatService) SetChatArchived(instanceID string, jid whatsapp.JID, archived bool) error {
+	chat := makeChat(instanceID, jid)
+	chat.Archived = archived
+	if archived {
+		return c.chatRepo.SaveChatState(chat, "archived", "pinned")
+	}
+	return c.chatRepo.SaveChatState(chat, "archived")
+}
+
+func (c *chatService) SetChatPinned(instanceID string, jid whatsapp.JID, pinned bool) error {
+	chat := makeChat(instanceID, jid)
+	chat.Pinned = pinned
+	return c.chatRepo.SaveChatState(chat, "pinned")
+}
+
+// SetChatMutedUntil mutes the chat until the given time, or unmutes it when nil.
+func (c *chatService) SetChatMutedUntil(instanceID string, jid whatsapp.JID, mutedUntil *time.Time) error {
+	chat := makeChat(instanceID, jid)
+	chat.MutedUntil = mutedUntil
+	return c.chatRepo.SaveChatState(chat, "muted_until")
+}
+
+// SetChatRead clears the unread count, or flags the chat as marked unread.
+func (c *chatService) SetChatRead(instanceID string, jid whatsapp.JID, read bool) error {
+	chat := makeChat(instanceID, jid)
+	if read {
+		return c.chatRepo.SaveChatState(chat, "unread_count", "marked_unread")
+	}
+	chat.MarkedUnread = true
+	return c.chatRepo.SaveChatState(chat, "marked_unread")
+}
+
+func (c *chatService) GetChat(instanceID string, chatJID string) (*model.Chat, error) {
+	return c.chatRepo.GetChat(instanceID, chatJID)
+}
+
+func (c *chatService) GetChats(query repository.ChatQuery) (*[]model.Chat, int64, error) {
+	return c.chatRepo.GetChats(query)
+}
+
+func (c *chatService) DeleteChat(instanceID string, chatJID string) error {
+	return c.chatRepo.DeleteChat(instanceID, chatJID)
+}
+
+func (c *chatService) DeleteChatsByInstanceID(instanceID string) error {
+	return c.chatRepo.DeleteChatsByInstanceID(instanceID)
+}
+
+func makeChat(instanceID string, jid whatsapp.JID) *model.Chat {
+	return &model.Chat{
+		InstanceID: instanceID,
+		ChatJID:    jid.User,
+		RemoteJID:  jid.String(),
+	}
+}
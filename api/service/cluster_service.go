@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"zapmeow/pkg/cluster"
+	"zapmeow/pkg/logger"
+)
+
+var ErrInstanceOnOtherNode = errors.New("instance is held by another node")
+
+type ClusterService interface {
+	Enabled() bool
+	NodeID() string
+	Acquire(instanceID string) error
+	Release(instanceID string)
+	Owner(instanceID string) (*cluster.Node, error)
+}
+
+type clusterService struct {
+	cluster cluster.Cluster
+}
+
+// NewClusterService takes a nil cluster when running a single node, in which
+// case every instance is local.
+func NewClusterService(cluster cluster.Cluster) *clusterService {
+	return &clusterService{
+		cluster: cluster,
+	}
+}
+
+func (c *clusterService) Enabled() bool {
+	return c.cluster != nil
+}
+
+func (c *clusterService) NodeID() string {
+	if !c.Enabled() {
+		return ""
+	}
+	return c.cluster.NodeID()
+}
+
+func (c *clusterService) Acquire(instanceID string) error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	err := c.cluster.Acquire(instanceID)
+	if errors.Is(err, cluster.ErrLeaseHeld) {
+		return ErrInstanceOnOtherNode
+	}
+	return err
+}
+
+func (c *clusterService) Release(instanceID string) {
+	if !c.Enabled() {
+		return
+	}
+
+	if err := c.cluster.Release(instanceID); err != nil {
+		logger.Error("Failed to release instance lease. ", err)
+	}
+}
+
+// Owner returns nil when the instance isn't held by any node.
+func (c *clusterService) Owner(instanceID string) (*cluster.Node, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	return c.cluster.Owner(instanceID)
+}
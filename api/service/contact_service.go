@@ -0,0 +1,220 @@
+package service
+
+import (
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
+	"zapmeow/pkg/whatsapp"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+type ContactService interface {
+	SetPushName(instanceID string, jid whatsapp.JID, pushName string) error
+	SetBusinessName(instanceID string, jid whatsapp.JID, businessName string) error
+	SetFullName(instanceID string, jid whatsapp.JID, fullName string, firstName string) error
+	SetPicture(instanceID string, jid whatsapp.JID, pictureURL string) error
+	ExpirePicture(instanceID string, jid whatsapp.JID) error
+	SetPictureFile(instanceID string, jid whatsapp.JID, picture whatsapp.ProfilePicture, path string) error
+	SavePushNames(instanceID string, pushNames map[whatsapp.JID]string) error
+	SaveUsersInfo(instanceID string, users map[whatsapp.JID]whatsapp.UserInfo) error
+	ImportContacts(instanceID string, contacts []whatsapp.Contact) error
+	GetContact(instanceID string, contactJID string) (*model.Contact, error)
+	GetContactsByJIDs(instanceID string, contactJIDs []string) (*[]model.Contact, error)
+	GetContacts(query repository.ContactQuery) (*[]model.Contact, int64, error)
+	DeleteContactsByInstanceID(instanceID string) error
+}
+
+type contactService struct {
+	contactRepo repository.ContactRepository
+}
+
+func NewContactService(contactRepo repository.ContactRepository) *contactService {
+	return &contactService{
+		contactRepo: contactRepo,
+	}
+}
+
+func (c *contactService) SetPushName(instanceID string, jid whatsapp.JID, pushName string) error {
+	contact := makeContact(instanceID, jid)
+	contact.PushName = pushName
+	return c.saveContact(jid, contact, "push_name")
+}
+
+func (c *contactService) SetBusinessName(instanceID string, jid whatsapp.JID, businessName string) error {
+	contact := makeContact(instanceID, jid)
+	contact.BusinessName = businessName
+	return c.saveContact(jid, contact, "business_name")
+}
+
+func (c *contactService) SetFullName(instanceID string, jid whatsapp.JID, fullName string, firstName string) error {
+	contact := makeContact(instanceID, jid)
+	contact.FullName = fullName
+	contact.FirstName = firstName
+	return c.saveContact(jid, contact, "full_name", "first_name")
+}
+
+// SetPicture stores the profile picture URL along with when it was fetched.
+// An empty URL means the contact has no picture, or hides it.
+func (c *contactService) SetPicture(instanceID string, jid whatsapp.JID, pictureURL string) error {
+	if pictureURL == "" {
+		if err := c.dropPictureFile(instanceID, jid); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	contact := makeContact(instanceID, jid)
+	contact.PictureURL = pictureURL
+	contact.PictureUpdatedAt = &now
+	return c.saveContact(jid, contact, "picture_url", "picture_updated_at")
+}
+
+// ExpirePicture marks the picture as unknown after it changed.
+func (c *contactService) ExpirePicture(instanceID string, jid whatsapp.JID) error {
+	if err := c.dropPictureFile(instanceID, jid); err != nil {
+		return err
+	}
+	return c.saveContact(
+		jid,
+		makeContact(instanceID, jid),
+		"picture_id", "picture_url", "picture_path", "picture_updated_at",
+	)
+}
+
+// SetPictureFile stores a downloaded profile picture.
+func (c *contactService) SetPictureFile(
+	instanceID string,
+	jid whatsapp.JID,
+	picture whatsapp.ProfilePicture,
+	path string,
+) error {
+	now := time.Now()
+	contact := makeContact(instanceID, jid)
+	contact.PictureID = picture.ID
+	contact.PictureURL = picture.URL
+	contact.PicturePath = path
+	contact.PictureUpdatedAt = &now
+	return c.saveContact(jid, contact, "picture_id", "picture_url", "picture_path", "picture_updated_at")
+}
+
+func (c *contactService) SavePushNames(instanceID string, pushNames map[whatsapp.JID]string) error {
+	contacts := []model.Contact{}
+	for jid, pushName := range pushNames {
+		if !isContactJID(jid) || pushName == "" {
+			continue
+		}
+		contact := makeContact(instanceID, jid)
+		contact.PushName = pushName
+		contacts = append(contacts, *contact)
+	}
+	return c.contactRepo.SaveContacts(&contacts, "push_name")
+}
+
+// SaveUsersInfo stores a user info lookup. A picture ID that differs from
+// the stored one invalidates the known picture, so it is fetched again.
+func (c *contactService) SaveUsersInfo(instanceID string, users map[whatsapp.JID]whatsapp.UserInfo) error {
+	contactJIDs := []string{}
+	for jid := range users {
+		contactJIDs = append(contactJIDs, jid.User)
+	}
+	stored, err := c.contactRepo.GetContactsByJIDs(instanceID, contactJIDs)
+	if err != nil {
+		return err
+	}
+	pictureIDs := map[string]model.Contact{}
+	for _, contact := range *stored {
+		pictureIDs[contact.ContactJID] = contact
+	}
+
+	unchanged := []model.Contact{}
+	changed := []model.Contact{}
+	for jid, info := range users {
+		if !isContactJID(jid) {
+			continue
+		}
+		contact := makeContact(instanceID, jid)
+		contact.BusinessName = info.BusinessName
+		contact.Status = info.Status
+		contact.PictureID = info.PictureID
+
+		old, ok := pictureIDs[jid.User]
+		if ok && old.PictureID == info.PictureID {
+			unchanged = append(unchanged, *contact)
+			continue
+		}
+		if ok && old.PicturePath != "" {
+			if err := removeFile(old.PicturePath); err != nil {
+				return err
+			}
+		}
+		changed = append(changed, *contact)
+	}
+
+	columns := []string{"business_name", "status", "picture_id"}
+	if err := c.contactRepo.SaveContacts(&unchanged, columns...); err != nil {
+		return err
+	}
+	return c.contactRepo.SaveContacts(&changed, append(columns, "picture_url", "picture_path", "picture_updated_at")...)
+}
+
+// ImportContacts saves the names known to the WhatsApp session store.
+func (c *contactService) ImportContacts(instanceID string, contacts []whatsapp.Contact) error {
+	data := []model.Contact{}
+	for _, info := range contacts {
+		if !isContactJID(info.JID) {
+			continue
+		}
+		contact := makeContact(instanceID, info.JID)
+		contact.PushName = info.PushName
+		contact.BusinessName = info.BusinessName
+		contact.FullName = info.FullName
+		contact.FirstName = info.FirstName
+		data = append(data, *contact)
+	}
+	return c.contactRepo.SaveContacts(&data, "push_name", "business_name", "full_name", "first_name")
+}
+
+func (c *contactService) GetContact(instanceID string, contactJID string) (*model.Contact, error) {
+	return c.contactRepo.GetContact(instanceID, contactJID)
+}
+
+func (c *contactService) GetContactsByJIDs(instanceID string, contactJIDs []string) (*[]model.Contact, error) {
+	return c.contactRepo.GetContactsByJIDs(instanceID, contactJIDs)
+}
+
+func (c *contactService) GetContacts(query repository.ContactQuery) (*[]model.Contact, int64, error) {
+	return c.contactRepo.GetContacts(query)
+}
+
+func (c *contactService) DeleteContactsByInstanceID(instanceID string) error {
+	return c.contactRepo.DeleteContactsByInstanceID(instanceID)
+}
+
+func (c *contactService) saveContact(jid whatsapp.JID, contact *model.Contact, columns ...string) error {
+	if !isContactJID(jid) {
+		return nil
+	}
+	return c.contactRepo.SaveContacts(&[]model.Contact{*contact}, columns...)
+}
+
+func (c *contactService) dropPictureFile(instanceID string, jid whatsapp.JID) error {
+	contact, err := c.contactRepo.GetContact(instanceID, jid.User)
+	if err != nil || contact == nil || contact.PicturePath == "" {
+		return err
+	}
+	return removeFile(contact.PicturePath)
+}
+
+func makeContact(instanceID string, jid whatsapp.JID) *model.Contact {
+	return &model.Contact{
+		InstanceID: instanceID,
+		ContactJID: jid.User,
+		RemoteJID:  jid.ToNonAD().String(),
+	}
+}
+
+// isContactJID leaves out groups, broadcasts and newsletters.
+func isContactJID(jid whatsapp.JID) bool {
+	return jid.User != "" && (jid.Server == types.DefaultUserServer || jid.Server == types.HiddenUserServer)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"slices"
+	"strings"
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
+	"zapmeow/pkg/whatsapp"
+	"zapmeow/pkg/zapmeow"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+var (
+	ErrMediaTooLarge       = errors.New("media exceeds the size limit")
+	ErrMediaMismatch       = errors.New("media content does not match its type")
+	ErrMediaTypeNotAllowed = errors.New("media type is not allowed")
+)
+
+type MediaService interface {
+	MaxMediaSize(mediaType whatsapp.MediaType) int64
+	ValidateMedia(mediaType whatsapp.MediaType, data []byte, mimetype string) (string, error)
+	GetRetentionPolicies(instanceID string) ([]model.MediaRetentionPolicy, error)
+	GetRetentionPolicy(instanceID string, mediaType string) (model.MediaRetentionPolicy, error)
+	SaveRetentionPolicy(policy *model.MediaRetentionPolicy) error
+	PurgeMedia(message model.Message, keepThumbnail bool, reason string) error
+	DeleteInstanceMedia(instanceID string) error
+}
+
+type mediaService struct {
+	app            *zapmeow.ZapMeow
+	mediaRepo      repository.MediaRepository
+	messageService MessageService
+}
+
+func NewMediaService(
+	app *zapmeow.ZapMeow,
+	mediaRepo repository.MediaRepository,
+	messageService MessageService,
+) *mediaService {
+	return &mediaService{
+		app:            app,
+		mediaRepo:      mediaRepo,
+		messageService: messageService,
+	}
+}
+
+func (m *mediaService) MaxMediaSize(mediaType whatsapp.MediaType) int64 {
+	switch mediaType {
+	case whatsapp.Image:
+		return m.app.Config.MaxImageSize
+	case whatsapp.Video:
+		return m.app.Config.MaxVideoSize
+	case whatsapp.Audio:
+		return m.app.Config.MaxAudioSize
+	case whatsapp.Document:
+		return m.app.Config.MaxDocumentSize
+	}
+	return 0
+}
+
+// ValidateMedia sniffs the media content and returns the mimetype to send
+// it with, correcting the declared one when the data says otherwise.
+func (m *mediaService) ValidateMedia(mediaType whatsapp.MediaType, data []byte, declared string) (string, error) {
+	limit := m.MaxMediaSize(mediaType)
+	if limit > 0 && int64(len(data)) > limit {
+		return "", fmt.Errorf("%w of %d bytes for %s", ErrMediaTooLarge, limit, mediaType)
+	}
+
+	declaredBase, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		declaredBase = declared
+	}
+
+	var detected []string
+	for mtype := mimetype.Detect(data); mtype != nil; mtype = mtype.Parent() {
+		base, _, _ := mime.ParseMediaType(mtype.String())
+		detected = append(detected, base)
+	}
+
+	var allowed func(string) bool
+	switch mediaType {
+	case whatsapp.Image, whatsapp.Video, whatsapp.Audio:
+		prefix := mediaType.String() + "/"
+		allowed = func(candidate string) bool {
+			return strings.HasPrefix(candidate, prefix)
+		}
+	case whatsapp.Document:
+		allowed = func(candidate string) bool {
+			return slices.Contains(m.app.Config.DocumentMimetypes, candidate)
+		}
+	default:
+		return declared, nil
+	}
+
+	if allowed(declaredBase) && slices.Contains(detected, declaredBase) {
+		return declared, nil
+	}
+
+	for _, candidate := range detected {
+		if !allowed(candidate) {
+			continue
+		}
+		if candidate == "audio/ogg" {
+			// whatsapp only plays voice notes with the codec declared
+			return candidate + "; codecs=opus", nil
+		}
+		return candidate, nil
+	}
+
+	if mediaType == whatsapp.Document {
+		return "", fmt.Errorf("%w: %s", ErrMediaTypeNotAllowed, detected[0])
+	}
+	return "", fmt.Errorf("%w: %s is not %s", ErrMediaMismatch, detected[0], mediaType)
+}
+
+func (m *mediaService) GetRetentionPolicies(instanceID string) ([]model.MediaRetentionPolicy, error) {
+	return m.mediaRepo.GetRetentionPolicies(instanceID)
+}
+
+// GetRetentionPolicy resolves the policy for a media type, preferring a
+// type-specific policy, then the instance-wide one, then the global config.
+func (m *mediaService) GetRetentionPolicy(instanceID string, mediaType string) (model.MediaRetentionPolicy, error) {
+	policy := model.MediaRetentionPolicy{
+		InstanceID:    instanceID,
+		MediaType:     mediaType,
+		MaxAgeDays:    m.app.Config.MediaRetentionDays,
+		MaxBytes:      m.app.Config.MediaMaxBytes,
+		KeepThumbnail: m.app.Config.MediaKeepThumbnails,
+	}
+
+	policies, err := m.mediaRepo.GetRetentionPolicies(instanceID)
+	if err != nil {
+		return policy, err
+	}
+
+	var instancePolicy, typePolicy *model.MediaRetentionPolicy
+	for i := range policies {
+		switch policies[i].MediaType {
+		case "":
+			instancePolicy = &policies[i]
+		case mediaType:
+			typePolicy = &policies[i]
+		}
+	}
+
+	if instancePolicy != nil {
+		policy.MaxAgeDays = instancePolicy.MaxAgeDays
+		policy.MaxBytes = instancePolicy.MaxBytes
+		policy.KeepThumbnail = instancePolicy.KeepThumbnail
+	}
+	if typePolicy != nil {
+		policy.MaxAgeDays = typePolicy.MaxAgeDays
+		policy.KeepThumbnail = typePolicy.KeepThumbnail
+	}
+
+	return policy, nil
+}
+
+func (m *mediaService) SaveRetentionPolicy(policy *model.MediaRetentionPolicy) error {
+	return m.mediaRepo.SaveRetentionPolicy(policy)
+}
+
+func (m *mediaService) PurgeMedia(message model.Message, keepThumbnail bool, reason string) error {
+	var size int64
+	if info, err := os.Stat(message.MediaPath); err == nil {
+		size = info.Size()
+	}
+
+	if err := removeFile(message.MediaPath); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"MediaPath":   "",
+		"MediaStatus": model.MediaStatusExpired,
+	}
+
+	if !keepThumbnail && message.ThumbnailPath != "" {
+		if err := removeFile(message.ThumbnailPath); err != nil {
+			return err
+		}
+		data["ThumbnailPath"] = ""
+	}
+
+	if err := m.messageService.UpdateMessage(message.ID, data); err != nil {
+		return err
+	}
+
+	return m.mediaRepo.CreateMediaPurge(&model.MediaPurge{
+		InstanceID: message.InstanceID,
+		MessageID:  message.MessageID,
+		MediaType:  message.MediaType,
+		MediaPath:  message.MediaPath,
+		Size:       size,
+		Reason:     reason,
+	})
+}
+
+// DeleteInstanceMedia drops the retention policies and purge history of an
+// instance. The files themselves go away with the account directory.
+func (m *mediaService) DeleteInstanceMedia(instanceID string) error {
+	return m.mediaRepo.DeleteInstanceMedia(instanceID)
+}
+
+func removeFile(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
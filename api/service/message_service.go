@@ -1,30 +1,55 @@
 package service
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
 	"zapmeow/api/model"
 	"zapmeow/api/repository"
+	"zapmeow/pkg/logger"
 )
 
 type MessageService interface {
 	CreateMessage(message *model.Message) error
 	CreateMessages(messages *[]model.Message) error
 	GetChatMessages(instanceID string, chatJID string) (*[]model.Message, error)
+	QueryChatMessages(query repository.MessageQuery) (*[]model.Message, error)
 	CountChatMessages(instanceID string, chatJID string) (int64, error)
+	GetMessage(id uint) (*model.Message, error)
+	GetMessageByMessageID(instanceID string, messageID string) (*model.Message, error)
+	GetMediaMessages(instanceID string) (*[]model.Message, error)
+	UpdateMessage(id uint, data map[string]interface{}) error
+	EditMessage(instanceID string, chatJID string, messageID string, body string, caption string, editedAt time.Time) error
+	RevokeMessage(instanceID string, chatJID string, messageID string) error
+	SearchMessages(search repository.MessageSearch) (*[]repository.MessageMatch, error)
 	DeleteMessagesByInstanceID(instanceID string) error
+	DeleteChatMessages(instanceID string, chatJID string) error
 }
 
 type messageService struct {
-	messageRep repository.MessageRepository
+	messageRep  repository.MessageRepository
+	chatService ChatService
 }
 
-func NewMessageService(messageRep repository.MessageRepository) *messageService {
+func NewMessageService(messageRep repository.MessageRepository, chatService ChatService) *messageService {
 	return &messageService{
-		messageRep: messageRep,
+		messageRep:  messageRep,
+		chatService: chatService,
 	}
 }
 
+// CreateMessage stores a new message and moves its chat up the chat list.
 func (m *messageService) CreateMessage(message *model.Message) error {
-	return m.messageRep.CreateMessage(message)
+	if err := m.messageRep.CreateMessage(message); err != nil {
+		return err
+	}
+
+	// the message is already stored, so the chat is only logged on failure
+	if err := m.chatService.UpdateChatFromMessage(*message); err != nil {
+		logger.Error("Failed to update chat. ", err)
+	}
+	return nil
 }
 
 func (m *messageService) CreateMessages(messages *[]model.Message) error {
@@ -35,10 +60,74 @@ func (m *messageService) GetChatMessages(instanceID string, chatJID string) (*[]
 	return m.messageRep.GetChatMessages(instanceID, chatJID)
 }
 
+func (m *messageService) QueryChatMessages(query repository.MessageQuery) (*[]model.Message, error) {
+	return m.messageRep.QueryChatMessages(query)
+}
+
 func (m *messageService) CountChatMessages(instanceID string, chatJID string) (int64, error) {
 	return m.messageRep.CountChatMessages(instanceID, chatJID)
 }
 
+func (m *messageService) GetMessage(id uint) (*model.Message, error) {
+	return m.messageRep.GetMessage(id)
+}
+
+func (m *messageService) GetMessageByMessageID(instanceID string, messageID string) (*model.Message, error) {
+	return m.messageRep.GetMessageByMessageID(instanceID, messageID)
+}
+
+func (m *messageService) GetMediaMessages(instanceID string) (*[]model.Message, error) {
+	return m.messageRep.GetMediaMessages(instanceID)
+}
+
+func (m *messageService) UpdateMessage(id uint, data map[string]interface{}) error {
+	return m.messageRep.UpdateMessage(id, data)
+}
+
+func (m *messageService) EditMessage(
+	instanceID string,
+	chatJID string,
+	messageID string,
+	body string,
+	caption string,
+	editedAt time.Time,
+) error {
+	return m.messageRep.UpdateChatMessage(instanceID, chatJID, messageID, map[string]interface{}{
+		"Body":     body,
+		"Caption":  caption,
+		"EditedAt": editedAt,
+	})
+}
+
+// RevokeMessage clears the text of a message deleted for everyone, which
+// also drops it from the search index.
+func (m *messageService) RevokeMessage(instanceID string, chatJID string, messageID string) error {
+	return m.messageRep.UpdateChatMessage(instanceID, chatJID, messageID, map[string]interface{}{
+		"Body":    "",
+		"Caption": "",
+		"Revoked": true,
+	})
+}
+
+func (m *messageService) SearchMessages(search repository.MessageSearch) (*[]repository.MessageMatch, error) {
+	return m.messageRep.SearchMessages(search)
+}
+
 func (m *messageService) DeleteMessagesByInstanceID(instanceID string) error {
 	return m.messageRep.DeleteMessagesByInstanceID(instanceID)
 }
+
+// DeleteChatMessages removes the messages of a chat along with their media.
+func (m *messageService) DeleteChatMessages(instanceID string, chatJID string) error {
+	files, err := m.messageRep.GetChatMediaFiles(instanceID, chatJID)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return m.messageRep.DeleteChatMessages(instanceID, chatJID)
+}
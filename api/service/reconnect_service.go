@@ -0,0 +1,128 @@
+package service
+
+import (
+	"sync"
+	"time"
+	"zapmeow/pkg/logger"
+	"zapmeow/pkg/whatsapp"
+	"zapmeow/pkg/zapmeow"
+)
+
+type ReconnectService interface {
+	Reconnect(instanceID string, state string, reason string, delay time.Duration)
+	ReportConnected(instanceID string)
+}
+
+type reconnectService struct {
+	app            *zapmeow.ZapMeow
+	accountService AccountService
+	webhookService WebhookService
+	whatsApp       whatsapp.WhatsApp
+	running        sync.Map
+}
+
+func NewReconnectService(
+	app *zapmeow.ZapMeow,
+	accountService AccountService,
+	webhookService WebhookService,
+	whatsApp whatsapp.WhatsApp,
+) *reconnectService {
+	return &reconnectService{
+		app:            app,
+		accountService: accountService,
+		webhookService: webhookService,
+		whatsApp:       whatsApp,
+	}
+}
+
+// Reconnect starts supervising a dropped instance until it logs in again.
+// The first attempt waits for delay, or for the backoff when it is zero.
+// Only one supervisor runs per instance, later calls are ignored.
+func (r *reconnectService) Reconnect(instanceID string, state string, reason string, delay time.Duration) {
+	instance := r.app.LoadInstance(instanceID)
+	if instance == nil {
+		return
+	}
+
+	if _, running := r.running.LoadOrStore(instanceID, struct{}{}); running {
+		return
+	}
+
+	r.report(instanceID, state, reason, 0)
+
+	go func() {
+		defer r.running.Delete(instanceID)
+		r.reconnect(instance, reason, delay)
+	}()
+}
+
+func (r *reconnectService) ReportConnected(instanceID string) {
+	r.report(instanceID, "connected", "", 0)
+}
+
+func (r *reconnectService) reconnect(instance *whatsapp.Instance, reason string, delay time.Duration) {
+	for attempt := 1; ; attempt++ {
+		maxAttempts := r.app.Config.ReconnectMaxAttempts
+		if maxAttempts > 0 && attempt > maxAttempts {
+			r.report(instance.ID, "failed", reason, attempt-1)
+			return
+		}
+
+		if delay == 0 {
+			delay = r.backoff(attempt)
+		}
+
+		select {
+		case <-*r.app.StopCh:
+			return
+		case <-time.After(delay):
+		}
+		delay = 0
+
+		// the instance was restarted, deleted or logged out meanwhile
+		if r.app.LoadInstance(instance.ID) != instance {
+			return
+		}
+
+		r.report(instance.ID, "reconnecting", reason, attempt)
+
+		err := r.whatsApp.Reconnect(instance)
+		if err == nil {
+			return
+		}
+
+		reason = err.Error()
+		logger.ErrorWithFields("Failed to reconnect instance. ", logger.Fields{
+			"instanceId": instance.ID,
+			"attempt":    attempt,
+			"error":      err,
+		})
+	}
+}
+
+func (r *reconnectService) backoff(attempt int) time.Duration {
+	delay := r.app.Config.ReconnectBaseDelay
+	for i := 1; i < attempt && delay < r.app.Config.ReconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, r.app.Config.ReconnectMaxDelay)
+}
+
+func (r *reconnectService) report(instanceID string, state string, reason string, attempts int) {
+	err := r.accountService.UpdateAccount(instanceID, map[string]interface{}{
+		"DisconnectReason":  reason,
+		"ReconnectAttempts": attempts,
+	})
+	if err != nil {
+		logger.Error("Failed to update account. ", err)
+	}
+
+	err = r.webhookService.Send(instanceID, "connection", map[string]interface{}{
+		"state":    state,
+		"reason":   reason,
+		"attempts": attempts,
+	})
+	if err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"zapmeow/pkg/zapmeow"
+)
+
+// Settings are the effective settings of an instance, after applying its
+// overrides on top of the global config.
+type Settings struct {
+	WebhookURL     string
+	HistorySync    bool
+	MaxMessageSync int
+	AutoRead       bool
+	RejectCalls    bool
+	// RejectCallMessage is texted to rejected callers, unless empty
+	RejectCallMessage string
+}
+
+type SettingsService interface {
+	GetSettings(instanceID string) (Settings, error)
+}
+
+type settingsService struct {
+	app            *zapmeow.ZapMeow
+	accountService AccountService
+}
+
+func NewSettingsService(
+	app *zapmeow.ZapMeow,
+	accountService AccountService,
+) *settingsService {
+	return &settingsService{
+		app:            app,
+		accountService: accountService,
+	}
+}
+
+// GetSettings reads the overrides on every call, so changes made through the
+// API apply to the next event without restarting the instance.
+func (s *settingsService) GetSettings(instanceID string) (Settings, error) {
+	settings := Settings{
+		WebhookURL:        s.app.Config.WebhookURL,
+		HistorySync:       s.app.Config.HistorySync,
+		MaxMessageSync:    s.app.Config.MaxMessageSync,
+		AutoRead:          s.app.Config.AutoRead,
+		RejectCalls:       s.app.Config.RejectCalls,
+		RejectCallMessage: s.app.Config.RejectCallMessage,
+	}
+
+	account, err := s.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil || account == nil {
+		return settings, err
+	}
+
+	overrides := account.Settings
+	if overrides.WebhookURL != nil {
+		settings.WebhookURL = *overrides.WebhookURL
+	}
+	if overrides.HistorySync != nil {
+		settings.HistorySync = *overrides.HistorySync
+	}
+	if overrides.MaxMessageSync != nil {
+		settings.MaxMessageSync = *overrides.MaxMessageSync
+	}
+	if overrides.AutoRead != nil {
+		settings.AutoRead = *overrides.AutoRead
+	}
+	if overrides.RejectCalls != nil {
+		settings.RejectCalls = *overrides.RejectCalls
+	}
+	if overrides.RejectCallMessage != nil {
+		settings.RejectCallMessage = *overrides.RejectCallMessage
+	}
+
+	return settings, nil
+}
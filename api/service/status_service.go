@@ -0,0 +1,83 @@
+package service
+
+import (
+	"zapmeow/api/model"
+	"zapmeow/api/repository"
+)
+
+type StatusService interface {
+	CreateStatus(status *model.Status) error
+	GetStatus(id uint) (*model.Status, error)
+	GetStatuses(query repository.StatusQuery) (*[]model.Status, int64, error)
+	UpdateStatus(id uint, data map[string]interface{}) error
+	RevokeStatus(instanceID string, senderJID string, statusID string) (*model.Status, error)
+	DeleteStatusesByInstanceID(instanceID string) error
+}
+
+type statusService struct {
+	statusRepo repository.StatusRepository
+}
+
+func NewStatusService(statusRepo repository.StatusRepository) *statusService {
+	return &statusService{
+		statusRepo: statusRepo,
+	}
+}
+
+func (s *statusService) CreateStatus(status *model.Status) error {
+	return s.statusRepo.CreateStatus(status)
+}
+
+func (s *statusService) GetStatus(id uint) (*model.Status, error) {
+	return s.statusRepo.GetStatus(id)
+}
+
+func (s *statusService) GetStatuses(query repository.StatusQuery) (*[]model.Status, int64, error) {
+	return s.statusRepo.GetStatuses(query)
+}
+
+func (s *statusService) UpdateStatus(id uint, data map[string]interface{}) error {
+	return s.statusRepo.UpdateStatus(id, data)
+}
+
+// RevokeStatus clears a status its sender deleted, along with its files.
+// It returns nil when the status was never stored.
+func (s *statusService) RevokeStatus(instanceID string, senderJID string, statusID string) (*model.Status, error) {
+	status, err := s.statusRepo.GetStatusByStatusID(instanceID, senderJID, statusID)
+	if err != nil || status == nil {
+		return nil, err
+	}
+
+	for _, path := range []string{status.MediaPath, status.ThumbnailPath} {
+		if path == "" {
+			continue
+		}
+		if err := removeFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	data := map[string]interface{}{
+		"Body":          "",
+		"Caption":       "",
+		"MediaPath":     "",
+		"ThumbnailPath": "",
+		"RawMessage":    nil,
+		"Revoked":       true,
+	}
+	if err := s.statusRepo.UpdateStatus(status.ID, data); err != nil {
+		return nil, err
+	}
+
+	status.Body = ""
+	status.Caption = ""
+	status.MediaPath = ""
+	status.ThumbnailPath = ""
+	status.RawMessage = nil
+	status.Revoked = true
+	return status, nil
+}
+
+func (s *statusService) DeleteStatusesByInstanceID(instanceID string) error {
+	return s.statusRepo.DeleteStatusesByInstanceID(instanceID)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"sync"
+	"zapmeow/pkg/http"
+	"zapmeow/pkg/zapmeow"
+)
+
+type WebhookService interface {
+	Send(instanceID string, event string, data map[string]interface{}) error
+	Wait()
+}
+
+type webhookService struct {
+	app             *zapmeow.ZapMeow
+	settingsService SettingsService
+	inFlight        sync.WaitGroup
+}
+
+func NewWebhookService(
+	app *zapmeow.ZapMeow,
+	settingsService SettingsService,
+) *webhookService {
+	return &webhookService{
+		app:             app,
+		settingsService: settingsService,
+	}
+}
+
+func (w *webhookService) Send(instanceID string, event string, data map[string]interface{}) error {
+	w.inFlight.Add(1)
+	defer w.inFlight.Done()
+
+	body := map[string]interface{}{
+		"instanceId": instanceID,
+		"event":      event,
+	}
+	for key, value := range data {
+		body[key] = value
+	}
+
+	settings, err := w.settingsService.GetSettings(instanceID)
+	if err != nil {
+		return err
+	}
+
+	if settings.WebhookURL == "" {
+		return nil
+	}
+	return http.Request(settings.WebhookURL, body)
+}
+
+// Wait blocks until every webhook being sent is delivered or has failed.
+func (w *webhookService) Wait() {
+	w.inFlight.Wait()
+}
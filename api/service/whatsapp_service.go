@@ -1,55 +1,150 @@
 package service
 
 import (
+	"errors"
+	"os"
+	"sync"
+	"time"
 	"zapmeow/api/helper"
 	"zapmeow/api/model"
 	"zapmeow/api/queue"
+	"zapmeow/api/repository"
 	"zapmeow/api/response"
 	"zapmeow/pkg/http"
 	"zapmeow/pkg/logger"
+	"zapmeow/pkg/pubsub"
 	"zapmeow/pkg/whatsapp"
 	"zapmeow/pkg/zapmeow"
 
 	"github.com/vincent-petithory/dataurl"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	"google.golang.org/protobuf/proto"
 )
 
+const (
+	// pictureWorkers bounds the concurrent profile picture downloads
+	pictureWorkers = 8
+	maxPictureSize = 5 << 20
+)
+
+var (
+	ErrMediaRetryRequested = errors.New("media expired, a re-upload was requested from the phone")
+	ErrAlreadyPaired       = errors.New("instance is already paired")
+	ErrInstanceNotFound    = errors.New("instance not found")
+	ErrInstanceExists      = errors.New("instance already exists")
+	ErrInvalidInstanceID   = errors.New("instance id must have 1 to 64 letters, digits, '-' or '_' and not be a reserved name")
+)
+
 type whatsAppService struct {
-	app            *zapmeow.ZapMeow
-	messageService MessageService
-	accountService AccountService
-	whatsApp       whatsapp.WhatsApp
+	app              *zapmeow.ZapMeow
+	messageService   MessageService
+	accountService   AccountService
+	chatService      ChatService
+	contactService   ContactService
+	statusService    StatusService
+	webhookService   WebhookService
+	settingsService  SettingsService
+	reconnectService ReconnectService
+	clusterService   ClusterService
+	whatsApp         whatsapp.WhatsApp
+	pairingEvents    pubsub.PubSub
 }
 
 type WhatsAppService interface {
 	GetInstance(instanceID string) (*whatsapp.Instance, error)
+	CreateInstance(instanceID string) (*whatsapp.Instance, error)
+	RestartInstance(instanceID string) (*whatsapp.Instance, error)
+	DeleteInstance(instanceID string) error
+	UnloadInstance(instanceID string)
+	DisconnectInstances()
+	SubscribePairing(instanceID string) (<-chan pubsub.Message, func())
 	IsAuthenticated(instance *whatsapp.Instance) bool
+	PairPhone(instance *whatsapp.Instance, phone string) (string, error)
 	Logout(instance *whatsapp.Instance) error
 	SendTextMessage(instance *whatsapp.Instance, jid whatsapp.JID, text string) (whatsapp.MessageResponse, error)
 	SendAudioMessage(instance *whatsapp.Instance, jid whatsapp.JID, audioURL *dataurl.DataURL, mimitype string) (whatsapp.MessageResponse, error)
 	SendDocumentMessage(instance *whatsapp.Instance, jid whatsapp.JID, documentURL *dataurl.DataURL, mimitype string, filename string) (whatsapp.MessageResponse, error)
 	SendImageMessage(instance *whatsapp.Instance, jid whatsapp.JID, imageURL *dataurl.DataURL, mimitype string) (whatsapp.MessageResponse, error)
 	GetContactInfo(instance *whatsapp.Instance, jid whatsapp.JID) (*whatsapp.ContactInfo, error)
+	GetContactsInfo(instance *whatsapp.Instance, jids []whatsapp.JID, downloadPictures bool) (*[]model.Contact, error)
 	ParseEventMessage(instance *whatsapp.Instance, message *events.Message) (whatsapp.Message, error)
+	DownloadMedia(instance *whatsapp.Instance, message *model.Message) error
+	RetryMedia(instance *whatsapp.Instance, message *model.Message) error
+	FailMedia(instanceID string, message *model.Message) error
 	IsOnWhatsApp(instance *whatsapp.Instance, phones []string) ([]whatsapp.IsOnWhatsAppResponse, error)
+	ArchiveChat(instance *whatsapp.Instance, jid whatsapp.JID, archive bool) error
+	PinChat(instance *whatsapp.Instance, jid whatsapp.JID, pin bool) error
+	MuteChat(instance *whatsapp.Instance, jid whatsapp.JID, mute bool, duration time.Duration) error
+	MarkChatRead(instance *whatsapp.Instance, jid whatsapp.JID, read bool) error
+	ClearChat(instance *whatsapp.Instance, jid whatsapp.JID) error
+	DeleteChat(instance *whatsapp.Instance, jid whatsapp.JID) error
+	GetBlocklist(instance *whatsapp.Instance) ([]whatsapp.JID, error)
+	SendTextStatus(instance *whatsapp.Instance, status whatsapp.TextStatus) (whatsapp.MessageResponse, error)
+	SendImageStatus(instance *whatsapp.Instance, imageURL *dataurl.DataURL, mimitype string, caption string) (whatsapp.MessageResponse, error)
+	SendVideoStatus(instance *whatsapp.Instance, videoURL *dataurl.DataURL, mimitype string, caption string) (whatsapp.MessageResponse, error)
+	GetStatusAudience(instance *whatsapp.Instance) (whatsapp.StatusAudience, error)
+	DownloadStatusMedia(instance *whatsapp.Instance, status *model.Status) error
+	FailStatusMedia(instanceID string, status *model.Status) error
+	CreateNewsletter(instance *whatsapp.Instance, name string, description string, picture []byte) (*whatsapp.Newsletter, error)
+	GetNewsletter(instance *whatsapp.Instance, jid whatsapp.JID) (*whatsapp.Newsletter, error)
+	GetNewsletterByInvite(instance *whatsapp.Instance, code string) (*whatsapp.Newsletter, error)
+	GetSubscribedNewsletters(instance *whatsapp.Instance) ([]whatsapp.Newsletter, error)
+	FollowNewsletter(instance *whatsapp.Instance, jid whatsapp.JID, follow bool) error
+	GetNewsletterMessages(instance *whatsapp.Instance, jid whatsapp.JID, count int, before int) ([]whatsapp.NewsletterMessage, error)
+	SendNewsletterText(instance *whatsapp.Instance, jid whatsapp.JID, text string) (whatsapp.MessageResponse, error)
+	SendNewsletterImage(instance *whatsapp.Instance, jid whatsapp.JID, imageURL *dataurl.DataURL, mimitype string, caption string) (whatsapp.MessageResponse, error)
+	SetPushName(instance *whatsapp.Instance, name string) error
+	SetAbout(instance *whatsapp.Instance, about string) error
+	SetProfilePicture(instance *whatsapp.Instance, picture []byte) (string, error)
+	GetPrivacySettings(instance *whatsapp.Instance) (whatsapp.PrivacySettings, error)
+	SetPrivacySettings(instance *whatsapp.Instance, settings whatsapp.PrivacySettings) (whatsapp.PrivacySettings, error)
+	UpdateBlocklist(instance *whatsapp.Instance, jid whatsapp.JID, block bool) ([]whatsapp.JID, error)
 }
 
 func NewWhatsAppService(
 	app *zapmeow.ZapMeow,
 	messageService MessageService,
 	accountService AccountService,
+	chatService ChatService,
+	contactService ContactService,
+	statusService StatusService,
+	webhookService WebhookService,
+	settingsService SettingsService,
+	reconnectService ReconnectService,
+	clusterService ClusterService,
 	whatsApp whatsapp.WhatsApp,
 ) *whatsAppService {
 	return &whatsAppService{
-		app:            app,
-		messageService: messageService,
-		accountService: accountService,
-		whatsApp:       whatsApp,
+		app:              app,
+		messageService:   messageService,
+		accountService:   accountService,
+		chatService:      chatService,
+		contactService:   contactService,
+		statusService:    statusService,
+		webhookService:   webhookService,
+		settingsService:  settingsService,
+		reconnectService: reconnectService,
+		clusterService:   clusterService,
+		whatsApp:         whatsApp,
+		pairingEvents:    pubsub.NewPubSub(),
 	}
 }
 
+// SubscribePairing streams the login progress of an instance: every new QR
+// code, then how the pairing ended.
+func (w *whatsAppService) SubscribePairing(instanceID string) (<-chan pubsub.Message, func()) {
+	return w.pairingEvents.Subscribe(instanceID)
+}
+
+func (w *whatsAppService) publishPairing(instanceID string, event string, data interface{}) {
+	w.pairingEvents.Publish(instanceID, pubsub.Message{
+		Event: event,
+		Data:  data,
+	})
+}
+
 func (w *whatsAppService) SendTextMessage(
 	instance *whatsapp.Instance,
 	jid whatsapp.JID,
@@ -86,14 +181,205 @@ func (w *whatsAppService) SendImageMessage(
 	return w.whatsApp.SendImageMessage(instance, jid, imageURL, mimitype)
 }
 
+// GetContactInfo also refreshes the stored contact with what was fetched.
 func (w *whatsAppService) GetContactInfo(instance *whatsapp.Instance, jid whatsapp.JID) (*whatsapp.ContactInfo, error) {
-	return w.whatsApp.GetContactInfo(instance, jid)
+	info, err := w.whatsApp.GetContactInfo(instance, jid)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Name != "" {
+		if err := w.contactService.SetPushName(instance.ID, jid, info.Name); err != nil {
+			logger.Error("Failed to update contact. ", err)
+		}
+	}
+	if err := w.contactService.SetPicture(instance.ID, jid, info.Picture); err != nil {
+		logger.Error("Failed to update contact. ", err)
+	}
+	return info, nil
+}
+
+// GetContactsInfo looks up many users at once and stores what was found.
+// With downloadPictures, profile pictures are saved to the media store; a
+// picture is only fetched again once its ID changes or the file is gone.
+func (w *whatsAppService) GetContactsInfo(
+	instance *whatsapp.Instance,
+	jids []whatsapp.JID,
+	downloadPictures bool,
+) (*[]model.Contact, error) {
+	users, err := w.whatsApp.GetUsersInfo(instance, jids)
+	if err != nil {
+		return nil, err
+	}
+
+	pushNames := map[whatsapp.JID]string{}
+	for jid, user := range users {
+		pushNames[jid] = user.PushName
+	}
+	if err := w.contactService.SavePushNames(instance.ID, pushNames); err != nil {
+		return nil, err
+	}
+	if err := w.contactService.SaveUsersInfo(instance.ID, users); err != nil {
+		return nil, err
+	}
+
+	contactJIDs := []string{}
+	for jid := range users {
+		contactJIDs = append(contactJIDs, jid.User)
+	}
+	contacts, err := w.contactService.GetContactsByJIDs(instance.ID, contactJIDs)
+	if err != nil || !downloadPictures {
+		return contacts, err
+	}
+
+	pending := make(chan model.Contact)
+	var wg sync.WaitGroup
+	for i := 0; i < pictureWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for contact := range pending {
+				if err := w.downloadPicture(instance, contact); err != nil {
+					logger.Error("Failed to download profile picture. ", err)
+				}
+			}
+		}()
+	}
+	for _, contact := range *contacts {
+		if contact.PictureID == "" || fileExists(contact.PicturePath) {
+			continue
+		}
+		pending <- contact
+	}
+	close(pending)
+	wg.Wait()
+
+	return w.contactService.GetContactsByJIDs(instance.ID, contactJIDs)
+}
+
+func (w *whatsAppService) downloadPicture(instance *whatsapp.Instance, contact model.Contact) error {
+	jid, err := types.ParseJID(contact.RemoteJID)
+	if err != nil {
+		return err
+	}
+
+	picture, err := w.whatsApp.GetProfilePicture(instance, jid)
+	if err != nil {
+		return err
+	}
+	if picture == nil {
+		return w.contactService.SetPicture(instance.ID, jid, "")
+	}
+
+	data, err := http.Download(picture.URL, maxPictureSize)
+	if err != nil {
+		return err
+	}
+
+	// WhatsApp always serves profile pictures as JPEG
+	path, err := helper.SaveMedia(instance.ID, "picture_"+contact.ContactJID, data, "image/jpeg")
+	if err != nil {
+		return err
+	}
+	return w.contactService.SetPictureFile(instance.ID, jid, *picture, path)
 }
 
 func (w *whatsAppService) ParseEventMessage(instance *whatsapp.Instance, message *events.Message) (whatsapp.Message, error) {
 	return w.whatsApp.ParseEventMessage(instance, message)
 }
 
+// DownloadMedia downloads and stores the media of a message. When the CDN
+// no longer has the file, it asks the phone to re-upload it instead and
+// returns ErrMediaRetryRequested; the download resumes on events.MediaRetry.
+func (w *whatsAppService) DownloadMedia(instance *whatsapp.Instance, message *model.Message) error {
+	err := w.downloadMedia(instance, message)
+	if err == nil || !whatsapp.IsMediaExpired(err) {
+		return err
+	}
+
+	if err := w.RetryMedia(instance, message); err != nil {
+		return err
+	}
+	return ErrMediaRetryRequested
+}
+
+func (w *whatsAppService) RetryMedia(instance *whatsapp.Instance, message *model.Message) error {
+	chat, err := types.ParseJID(message.RemoteJID)
+	if err != nil {
+		return err
+	}
+
+	sender, err := types.ParseJID(message.ParticipantJID)
+	if err != nil {
+		return err
+	}
+
+	err = w.whatsApp.RequestMediaRetry(instance, message.RawMessage, whatsapp.MediaRetryInfo{
+		MessageID: message.MessageID,
+		Chat:      chat,
+		Sender:    sender,
+		FromMe:    message.FromMe,
+	})
+	if err != nil {
+		return err
+	}
+
+	message.MediaStatus = model.MediaStatusRetry
+	return w.messageService.UpdateMessage(message.ID, map[string]interface{}{
+		"MediaStatus": model.MediaStatusRetry,
+	})
+}
+
+func (w *whatsAppService) FailMedia(instanceID string, message *model.Message) error {
+	err := w.messageService.UpdateMessage(message.ID, map[string]interface{}{
+		"MediaStatus": model.MediaStatusFailed,
+	})
+	if err != nil {
+		return err
+	}
+
+	message.MediaStatus = model.MediaStatusFailed
+	return w.webhookService.Send(instanceID, "media", map[string]interface{}{
+		"message": response.NewMessageResponse(*message),
+	})
+}
+
+func (w *whatsAppService) downloadMedia(instance *whatsapp.Instance, message *model.Message) error {
+	media, err := w.whatsApp.DownloadMedia(instance, message.RawMessage)
+	if err != nil {
+		return err
+	}
+
+	path, err := helper.SaveMedia(
+		instance.ID,
+		message.MessageID,
+		media.Data,
+		media.Mimetype,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = w.messageService.UpdateMessage(message.ID, map[string]interface{}{
+		"MediaPath":   path,
+		"MediaStatus": model.MediaStatusReady,
+	})
+	if err != nil {
+		return err
+	}
+
+	message.MediaPath = path
+	message.MediaStatus = model.MediaStatusReady
+
+	err = w.webhookService.Send(instance.ID, "media", map[string]interface{}{
+		"message": response.NewMessageResponse(*message),
+	})
+	if err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+	return nil
+}
+
 func (w *whatsAppService) IsOnWhatsApp(instance *whatsapp.Instance, phones []string) ([]whatsapp.IsOnWhatsAppResponse, error) {
 	return w.whatsApp.IsOnWhatsApp(instance, phones)
 }
@@ -104,8 +390,13 @@ func (w *whatsAppService) GetInstance(instanceID string) (*whatsapp.Instance, er
 		return instance, nil
 	}
 
+	if err := w.clusterService.Acquire(instanceID); err != nil {
+		return nil, err
+	}
+
 	instance, err := w.gerOrCreateInstance(instanceID)
 	if err != nil {
+		w.clusterService.Release(instanceID)
 		return nil, err
 	}
 	w.app.StoreInstance(instanceID, instance)
@@ -119,21 +410,34 @@ func (w *whatsAppService) GetInstance(instanceID string) (*whatsapp.Instance, er
 		switch event {
 		case "code":
 			{
+				status := "UNPAIRED"
+				if instance.Pairing.Load() {
+					status = "PAIRING"
+				}
+
 				err = w.accountService.UpdateAccount(instanceID, map[string]interface{}{
 					"QrCode":    code,
-					"Status":    "UNPAIRED",
+					"Status":    status,
 					"WasSynced": false,
 				})
 				if err != nil {
 					logger.Error("Failed to update account. ", err)
 				}
+
+				w.publishPairing(instanceID, "code", map[string]interface{}{
+					"qrcode": code,
+				})
 			}
 		case "error":
 			{
 				logger.Error("Qrcode. ", err)
+				w.publishPairing(instanceID, "error", map[string]interface{}{
+					"error": err.Error(),
+				})
 			}
 		case "rate-limit":
 			{
+				w.publishPairing(instanceID, "rate-limit", nil)
 				err := w.deleteInstance(instance)
 				if err != nil {
 					logger.Error("Failed to destroy instance. ", err)
@@ -150,22 +454,152 @@ func (w *whatsAppService) GetInstance(instanceID string) (*whatsapp.Instance, er
 					logger.Error("Failed to update account. ", err)
 				}
 
+				w.publishPairing(instanceID, "timeout", nil)
+
 				w.deleteInstance(instance)
 			}
 
 		}
 	})
 	if err != nil {
-		return nil, err
+		if instance.Client.Store.ID == nil {
+			return nil, err
+		}
+
+		// a paired instance keeps retrying in the background instead of
+		// staying offline until someone restarts it
+		w.reconnectService.Reconnect(instanceID, "disconnected", err.Error(), 0)
 	}
 
 	return instance, nil
 }
 
+func (w *whatsAppService) CreateInstance(instanceID string) (*whatsapp.Instance, error) {
+	if !helper.IsValidInstanceID(instanceID) {
+		return nil, ErrInvalidInstanceID
+	}
+
+	account, err := w.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != nil {
+		return nil, ErrInstanceExists
+	}
+
+	err = w.accountService.CreateAccount(&model.Account{
+		InstanceID: instanceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w.GetInstance(instanceID)
+}
+
+func (w *whatsAppService) RestartInstance(instanceID string) (*whatsapp.Instance, error) {
+	account, err := w.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, ErrInstanceNotFound
+	}
+
+	instance := w.app.LoadInstance(instanceID)
+	if instance != nil {
+		w.whatsApp.Disconnect(instance)
+		w.app.DeleteInstance(instanceID)
+	}
+	return w.GetInstance(instanceID)
+}
+
+// DeleteInstance logs the instance out and wipes its device store, messages,
+// media and account.
+func (w *whatsAppService) DeleteInstance(instanceID string) error {
+	account, err := w.accountService.GetAccountByInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return ErrInstanceNotFound
+	}
+
+	instance := w.app.LoadInstance(instanceID)
+	if instance == nil {
+		instance = w.whatsApp.CreateInstanceFromDevice(instanceID, makeDeviceJID(account))
+	}
+
+	if w.IsAuthenticated(instance) {
+		err := w.whatsApp.Logout(instance)
+		if err != nil {
+			logger.Error("Failed to logout. ", err)
+		}
+	}
+
+	w.whatsApp.Disconnect(instance)
+	w.app.DeleteInstance(instanceID)
+	w.clusterService.Release(instanceID)
+
+	err = w.whatsApp.DeleteDevice(instance)
+	if err != nil {
+		return err
+	}
+	return w.accountService.DeleteAccount(instanceID)
+}
+
+// UnloadInstance stops running the instance on this node, keeping its
+// session and data so another node can take it over.
+func (w *whatsAppService) UnloadInstance(instanceID string) {
+	instance := w.app.LoadInstance(instanceID)
+	if instance == nil {
+		return
+	}
+
+	w.whatsApp.Disconnect(instance)
+	w.app.DeleteInstance(instanceID)
+	w.clusterService.Release(instanceID)
+}
+
+// DisconnectInstances closes the websocket of every loaded instance, keeping
+// their sessions so they resume on the next start.
+func (w *whatsAppService) DisconnectInstances() {
+	w.app.Instances.Range(func(key, value interface{}) bool {
+		instance := value.(*whatsapp.Instance)
+		logger.Info("Disconnecting instance: ", instance.ID)
+		w.whatsApp.Disconnect(instance)
+		w.clusterService.Release(instance.ID)
+		return true
+	})
+}
+
 func (w *whatsAppService) IsAuthenticated(instance *whatsapp.Instance) bool {
 	return w.whatsApp.IsConnected(instance) && w.whatsApp.IsLoggedIn(instance)
 }
 
+func (w *whatsAppService) PairPhone(instance *whatsapp.Instance, phone string) (string, error) {
+	if w.whatsApp.IsLoggedIn(instance) {
+		return "", ErrAlreadyPaired
+	}
+
+	code, err := w.whatsApp.PairPhone(instance, phone)
+	if err != nil {
+		return "", err
+	}
+
+	instance.Pairing.Store(true)
+	err = w.accountService.UpdateAccount(instance.ID, map[string]interface{}{
+		"QrCode": "",
+		"Status": "PAIRING",
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
 func (w *whatsAppService) Logout(instance *whatsapp.Instance) error {
 	err := w.whatsApp.Logout(instance)
 	if err != nil {
@@ -188,8 +622,13 @@ func (w *whatsAppService) gerOrCreateInstance(instanceID string) (*whatsapp.Inst
 		return nil, err
 	}
 
-	if account == nil || (account != nil && account.Status != "CONNECTED") {
-		instance := w.whatsApp.CreateInstance(instanceID)
+	if account == nil {
+		if w.app.Config.StrictInstances {
+			return nil, ErrInstanceNotFound
+		}
+		if !helper.IsValidInstanceID(instanceID) {
+			return nil, ErrInvalidInstanceID
+		}
 
 		err := w.accountService.CreateAccount(&model.Account{
 			InstanceID: instanceID,
@@ -197,21 +636,28 @@ func (w *whatsAppService) gerOrCreateInstance(instanceID string) (*whatsapp.Inst
 		if err != nil {
 			return nil, err
 		}
-		return instance, nil
+		return w.whatsApp.CreateInstance(instanceID), nil
+	}
+
+	if account.Status != "CONNECTED" {
+		return w.whatsApp.CreateInstance(instanceID), nil
 	}
 
-	jid := types.JID{
+	instance := w.whatsApp.CreateInstanceFromDevice(
+		instanceID,
+		makeDeviceJID(account),
+	)
+	return instance, nil
+}
+
+func makeDeviceJID(account *model.Account) whatsapp.JID {
+	return types.JID{
 		User:       account.User,
 		RawAgent:   account.RawAgent,
 		Device:     account.Device,
 		Integrator: account.Integrator,
 		Server:     account.Server,
 	}
-	instance := w.whatsApp.CreateInstanceFromDevice(
-		instanceID,
-		jid,
-	)
-	return instance, nil
 }
 
 func (w *whatsAppService) deleteInstance(instance *whatsapp.Instance) error {
@@ -222,6 +668,7 @@ func (w *whatsAppService) deleteInstance(instance *whatsapp.Instance) error {
 
 	w.whatsApp.Disconnect(instance)
 	w.app.DeleteInstance(instance.ID)
+	w.clusterService.Release(instance.ID)
 	return nil
 }
 
@@ -233,19 +680,95 @@ func (w *whatsAppService) eventHandler(instanceID string, rawEvt interface{}) {
 		w.handleHistorySync(instanceID, evt)
 	case *events.Connected:
 		w.handleConnected(instanceID)
+	case *events.PairSuccess:
+		w.handlePairSuccess(instanceID)
+	case *events.PairError:
+		w.handlePairError(instanceID, evt)
 	case *events.LoggedOut:
 		w.handleLoggedOut(instanceID)
+	case *events.MediaRetry:
+		w.handleMediaRetry(instanceID, evt)
+	case *events.CallOffer:
+		w.handleCallOffer(instanceID, evt)
+	case *events.CallOfferNotice:
+		w.sendCallWebhook(instanceID, "offer", evt.BasicCallMeta, map[string]interface{}{
+			"video":    evt.Media == "video",
+			"rejected": false,
+		})
+	case *events.CallAccept:
+		w.sendCallWebhook(instanceID, "accept", evt.BasicCallMeta, nil)
+	case *events.CallReject:
+		w.sendCallWebhook(instanceID, "reject", evt.BasicCallMeta, nil)
+	case *events.CallTerminate:
+		w.sendCallWebhook(instanceID, "terminate", evt.BasicCallMeta, map[string]interface{}{
+			"reason": evt.Reason,
+		})
+	case *events.Receipt:
+		w.handleReceipt(instanceID, evt)
+	case *events.PushName:
+		w.handlePushName(instanceID, evt)
+	case *events.BusinessName:
+		w.handleBusinessName(instanceID, evt)
+	case *events.Contact:
+		w.handleContact(instanceID, evt)
+	case *events.Picture:
+		w.handlePicture(instanceID, evt)
+	case *events.Archive:
+		w.handleArchive(instanceID, evt)
+	case *events.Pin:
+		w.handlePin(instanceID, evt)
+	case *events.Mute:
+		w.handleMute(instanceID, evt)
+	case *events.MarkChatAsRead:
+		w.handleMarkChatAsRead(instanceID, evt)
+	case *events.ClearChat:
+		w.handleClearChat(instanceID, evt)
+	case *events.DeleteChat:
+		w.handleDeleteChat(instanceID, evt)
+	case *events.Blocklist:
+		w.handleBlocklist(instanceID, evt)
+	case *events.NewsletterJoin:
+		newsletter := w.whatsApp.MakeNewsletter(&evt.NewsletterMetadata)
+		w.sendNewsletterWebhook(instanceID, "join", map[string]interface{}{
+			"newsletter": response.NewNewsletterResponse(newsletter),
+		})
+	case *events.NewsletterLeave:
+		w.sendNewsletterWebhook(instanceID, "leave", map[string]interface{}{
+			"id":   evt.ID.String(),
+			"role": evt.Role,
+		})
+	case *events.NewsletterMuteChange:
+		w.sendNewsletterWebhook(instanceID, "mute", map[string]interface{}{
+			"id":    evt.ID.String(),
+			"muted": evt.Mute == types.NewsletterMuteOn,
+		})
+	case *events.Disconnected:
+		w.reconnectService.Reconnect(instanceID, "disconnected", "connection lost", 0)
+	case *events.StreamReplaced:
+		w.reconnectService.Reconnect(instanceID, "disconnected", "stream replaced by another client", 0)
+	case *events.TemporaryBan:
+		w.reconnectService.Reconnect(instanceID, "banned", evt.String(), evt.Expire)
+	case *events.ConnectFailure:
+		w.handleConnectFailure(instanceID, evt)
+	case *events.KeepAliveTimeout:
+		w.handleKeepAliveTimeout(instanceID, evt)
 	}
 }
 
 func (w *whatsAppService) handleHistorySync(instanceID string, evt *events.HistorySync) {
-	if !w.app.Config.HistorySync {
+	settings, err := w.settingsService.GetSettings(instanceID)
+	if err != nil {
+		logger.Error("Failed to get settings. ", err)
+		return
+	}
+
+	if !settings.HistorySync {
 		return
 	}
 	history, _ := proto.Marshal(evt.Data)
 
 	q := queue.NewHistorySyncQueue(w.app)
-	err := q.Enqueue(queue.HistorySyncQueueData{
+	err = q.Enqueue(queue.HistorySyncQueueData{
 		History:    history,
 		InstanceID: instanceID,
 	})
@@ -255,78 +778,364 @@ func (w *whatsAppService) handleHistorySync(instanceID string, evt *events.Histo
 	}
 }
 
-func (w *whatsAppService) handleConnected(instanceID string) {
-	var instance = w.app.LoadInstance(instanceID)
-	err := w.accountService.UpdateAccount(instanceID, map[string]interface{}{
-		"User":       instance.Client.Store.ID.User,
-		"RawAgent":   instance.Client.Store.ID.RawAgent,
-		"Device":     instance.Client.Store.ID.Device,
-		"Server":     instance.Client.Store.ID.Server,
-		"Integrator": instance.Client.Store.ID.Integrator,
-		"InstanceID": instance.ID,
-		"Status":     "CONNECTED",
-		"QrCode":     "",
-		"WasSynced":  false,
-	})
+// handleReceipt clears the unread count of chats read on another device.
+func (w *whatsAppService) handleReceipt(instanceID string, evt *events.Receipt) {
+	if evt.Type != types.ReceiptTypeReadSelf {
+		return
+	}
 
+	err := w.chatService.MarkChatRead(instanceID, evt.Chat.User)
 	if err != nil {
-		logger.Error("Failed to update account. ", err)
+		logger.Error("Failed to mark chat as read. ", err)
 	}
 }
 
-func (w *whatsAppService) handleLoggedOut(instanceID string) {
-	instance, err := w.GetInstance(instanceID)
+// handleCallOffer rejects 1:1 calls when the instance is set to, texting
+// the caller the configured reply. The webhook tells whether it was rejected.
+func (w *whatsAppService) handleCallOffer(instanceID string, evt *events.CallOffer) {
+	settings, err := w.settingsService.GetSettings(instanceID)
 	if err != nil {
-		logger.Error(err)
+		logger.Error("Failed to get settings. ", err)
 		return
 	}
 
-	err = w.deleteInstance(instance)
-	if err != nil {
-		logger.Error(err)
+	rejected := false
+	if settings.RejectCalls {
+		instance := w.app.LoadInstance(instanceID)
+		err = w.whatsApp.RejectCall(instance, evt.CallCreator, evt.CallID)
+		if err != nil {
+			logger.Error("Failed to reject call. ", err)
+		} else {
+			rejected = true
+		}
 	}
 
-	err = w.accountService.UpdateAccount(instanceID, map[string]interface{}{
-		"Status": "UNPAIRED",
+	video := evt.Data != nil && len(evt.Data.GetChildrenByTag("video")) > 0
+	w.sendCallWebhook(instanceID, "offer", evt.BasicCallMeta, map[string]interface{}{
+		"video":    video,
+		"rejected": rejected,
 	})
-	if err != nil {
-		logger.Error("Failed to update account. ", err)
+
+	if rejected && settings.RejectCallMessage != "" {
+		w.replyToCall(instanceID, evt.CallCreator, settings.RejectCallMessage)
 	}
 }
 
-func (w *whatsAppService) handleMessage(instanceId string, evt *events.Message) {
-	instance := w.app.LoadInstance(instanceId)
-	parsedEventMessage, err := w.whatsApp.ParseEventMessage(instance, evt)
+// replyToCall stores the reply like any message sent through the API.
+func (w *whatsAppService) replyToCall(instanceID string, caller whatsapp.JID, text string) {
+	instance := w.app.LoadInstance(instanceID)
+	jid := caller.ToNonAD()
 
+	resp, err := w.whatsApp.SendTextMessage(instance, jid, text)
 	if err != nil {
-		logger.Error(err)
+		logger.Error("Failed to send call reply. ", err)
 		return
 	}
 
-	message := model.Message{
-		SenderJID:  parsedEventMessage.SenderJID,
-		ChatJID:    parsedEventMessage.ChatJID,
-		InstanceID: parsedEventMessage.InstanceID,
-		MessageID:  parsedEventMessage.MessageID,
-		Timestamp:  parsedEventMessage.Timestamp,
-		Body:       parsedEventMessage.Body,
-		FromMe:     parsedEventMessage.FromMe,
+	err = w.messageService.CreateMessage(&model.Message{
+		MessageID:      resp.ID,
+		ChatJID:        jid.User,
+		SenderJID:      resp.Sender.User,
+		RemoteJID:      jid.String(),
+		ParticipantJID: resp.Sender.String(),
+		InstanceID:     instanceID,
+		Body:           text,
+		Timestamp:      resp.Timestamp,
+		FromMe:         true,
+	})
+	if err != nil {
+		logger.Error("Failed to create message. ", err)
 	}
+}
 
-	if parsedEventMessage.MediaType != nil {
-		path, err := helper.SaveMedia(
-			instance.ID,
+func (w *whatsAppService) sendCallWebhook(
+	instanceID string,
+	action string,
+	meta types.BasicCallMeta,
+	extra map[string]interface{},
+) {
+	data := map[string]interface{}{
+		"action": action,
+		"call":   response.NewCallResponse(meta),
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+
+	if err := w.webhookService.Send(instanceID, "call", data); err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+}
+
+func (w *whatsAppService) handleConnectFailure(instanceID string, evt *events.ConnectFailure) {
+	// logouts are followed by a LoggedOut event, there is nothing to resume
+	if evt.Reason.IsLoggedOut() {
+		return
+	}
+
+	reason := evt.Reason.String()
+	if evt.Message != "" {
+		reason += ": " + evt.Message
+	}
+	w.reconnectService.Reconnect(instanceID, "disconnected", reason, 0)
+}
+
+// handleKeepAliveTimeout forces a reconnect when the websocket looks alive
+// but the server has stopped answering the keepalive pings.
+func (w *whatsAppService) handleKeepAliveTimeout(instanceID string, evt *events.KeepAliveTimeout) {
+	if time.Since(evt.LastSuccess) < whatsmeow.KeepAliveMaxFailTime {
+		return
+	}
+	w.reconnectService.Reconnect(instanceID, "disconnected", "keepalive timed out", 0)
+}
+
+func (w *whatsAppService) handleConnected(instanceID string) {
+	var instance = w.app.LoadInstance(instanceID)
+	err := w.accountService.UpdateAccount(instanceID, map[string]interface{}{
+		"User":       instance.Client.Store.ID.User,
+		"RawAgent":   instance.Client.Store.ID.RawAgent,
+		"Device":     instance.Client.Store.ID.Device,
+		"Server":     instance.Client.Store.ID.Server,
+		"Integrator": instance.Client.Store.ID.Integrator,
+		"InstanceID": instance.ID,
+		"Status":     "CONNECTED",
+		"QrCode":     "",
+		"WasSynced":  false,
+	})
+
+	if err != nil {
+		logger.Error("Failed to update account. ", err)
+	}
+
+	w.reconnectService.ReportConnected(instanceID)
+	w.publishPairing(instanceID, "connected", nil)
+
+	w.importContacts(instance)
+}
+
+// importContacts copies the names the session store learned while the
+// instance was not running.
+func (w *whatsAppService) importContacts(instance *whatsapp.Instance) {
+	contacts, err := w.whatsApp.GetContacts(instance)
+	if err != nil {
+		logger.Error("Failed to get contacts. ", err)
+		return
+	}
+
+	if err := w.contactService.ImportContacts(instance.ID, contacts); err != nil {
+		logger.Error("Failed to import contacts. ", err)
+	}
+}
+
+func (w *whatsAppService) handlePushName(instanceID string, evt *events.PushName) {
+	if err := w.contactService.SetPushName(instanceID, evt.JID, evt.NewPushName); err != nil {
+		logger.Error("Failed to update contact push name. ", err)
+	}
+}
+
+func (w *whatsAppService) handleBusinessName(instanceID string, evt *events.BusinessName) {
+	if err := w.contactService.SetBusinessName(instanceID, evt.JID, evt.NewBusinessName); err != nil {
+		logger.Error("Failed to update contact business name. ", err)
+	}
+}
+
+// handleContact stores an address book change made on the phone.
+func (w *whatsAppService) handleContact(instanceID string, evt *events.Contact) {
+	err := w.contactService.SetFullName(
+		instanceID,
+		evt.JID,
+		evt.Action.GetFullName(),
+		evt.Action.GetFirstName(),
+	)
+	if err != nil {
+		logger.Error("Failed to update contact. ", err)
+	}
+}
+
+// handlePicture forgets the stored picture URL of a changed picture, which
+// is fetched again with the contact info.
+func (w *whatsAppService) handlePicture(instanceID string, evt *events.Picture) {
+	var err error
+	if evt.Remove {
+		err = w.contactService.SetPicture(instanceID, evt.JID, "")
+	} else {
+		err = w.contactService.ExpirePicture(instanceID, evt.JID)
+	}
+	if err != nil {
+		logger.Error("Failed to update contact picture. ", err)
+	}
+}
+
+func (w *whatsAppService) handlePairSuccess(instanceID string) {
+	err := w.accountService.UpdateAccount(instanceID, map[string]interface{}{
+		"QrCode": "",
+		"Status": "PAIRED",
+	})
+	if err != nil {
+		logger.Error("Failed to update account. ", err)
+	}
+
+	w.publishPairing(instanceID, "paired", nil)
+}
+
+func (w *whatsAppService) handlePairError(instanceID string, evt *events.PairError) {
+	logger.Error("Failed to pair. ", evt.Error)
+
+	err := w.accountService.UpdateAccount(instanceID, map[string]interface{}{
+		"QrCode": "",
+		"Status": "PAIR_ERROR",
+	})
+	if err != nil {
+		logger.Error("Failed to update account. ", err)
+	}
+
+	w.publishPairing(instanceID, "error", map[string]interface{}{
+		"error": evt.Error.Error(),
+	})
+}
+
+func (w *whatsAppService) handleLoggedOut(instanceID string) {
+	instance, err := w.GetInstance(instanceID)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	err = w.deleteInstance(instance)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	err = w.accountService.UpdateAccount(instanceID, map[string]interface{}{
+		"Status": "UNPAIRED",
+	})
+	if err != nil {
+		logger.Error("Failed to update account. ", err)
+	}
+}
+
+func (w *whatsAppService) handleMediaRetry(instanceID string, evt *events.MediaRetry) {
+	instance := w.app.LoadInstance(instanceID)
+	message, err := w.messageService.GetMessageByMessageID(instanceID, evt.MessageID)
+	if err != nil {
+		logger.Error("Failed to get message. ", err)
+		return
+	}
+
+	if message == nil || message.MediaStatus != model.MediaStatusRetry {
+		return
+	}
+
+	raw, err := w.whatsApp.ApplyMediaRetry(message.RawMessage, evt)
+	if err == nil {
+		message.RawMessage = raw
+		err = w.messageService.UpdateMessage(message.ID, map[string]interface{}{
+			"RawMessage": raw,
+		})
+	}
+	if err == nil {
+		err = w.downloadMedia(instance, message)
+	}
+
+	if err != nil {
+		logger.ErrorWithFields("Failed to download media after retry. ", logger.Fields{
+			"instanceId": instanceID,
+			"messageId":  message.MessageID,
+			"error":      err,
+		})
+
+		if err := w.FailMedia(instanceID, message); err != nil {
+			logger.Error("Failed to update media status. ", err)
+		}
+	}
+}
+
+func (w *whatsAppService) handleMessage(instanceId string, evt *events.Message) {
+	// channel posts are not stored, the history is fetched from WhatsApp
+	if evt.Info.Chat.Server == types.NewsletterServer {
+		w.sendNewsletterWebhook(instanceId, "message", map[string]interface{}{
+			"id":      evt.Info.Chat.String(),
+			"message": response.NewNewsletterMessageResponse(w.whatsApp.ParseNewsletterMessage(evt)),
+		})
+		return
+	}
+
+	instance := w.app.LoadInstance(instanceId)
+	parsedEventMessage, err := w.whatsApp.ParseEventMessage(instance, evt)
+
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	// statuses are kept apart from the chats
+	if evt.Info.Chat == types.StatusBroadcastJID {
+		w.handleStatus(instance, parsedEventMessage)
+		return
+	}
+
+	if parsedEventMessage.Revoked {
+		err := w.messageService.RevokeMessage(instanceId, parsedEventMessage.ChatJID, parsedEventMessage.MessageID)
+		if err != nil {
+			logger.Error("Failed to revoke message. ", err)
+		}
+		return
+	}
+
+	if parsedEventMessage.Edited {
+		err := w.messageService.EditMessage(
+			instanceId,
+			parsedEventMessage.ChatJID,
 			parsedEventMessage.MessageID,
-			*parsedEventMessage.Media,
-			*parsedEventMessage.Mimetype,
+			parsedEventMessage.Body,
+			parsedEventMessage.Caption,
+			parsedEventMessage.Timestamp,
 		)
-
 		if err != nil {
-			logger.Error("Failed to save media. ", err)
+			logger.Error("Failed to edit message. ", err)
 		}
+		return
+	}
+
+	// redelivered after a reconnect, it was already stored and sent
+	existing, err := w.messageService.GetMessageByMessageID(instanceId, parsedEventMessage.MessageID)
+	if err != nil {
+		logger.Error("Failed to get message. ", err)
+		return
+	}
+	if existing != nil && existing.ChatJID == parsedEventMessage.ChatJID {
+		return
+	}
 
+	message := model.Message{
+		SenderJID:      parsedEventMessage.SenderJID,
+		ChatJID:        parsedEventMessage.ChatJID,
+		RemoteJID:      parsedEventMessage.Chat.String(),
+		ParticipantJID: parsedEventMessage.Sender.String(),
+		InstanceID:     parsedEventMessage.InstanceID,
+		MessageID:      parsedEventMessage.MessageID,
+		Timestamp:      parsedEventMessage.Timestamp,
+		Body:           parsedEventMessage.Body,
+		Caption:        parsedEventMessage.Caption,
+		FromMe:         parsedEventMessage.FromMe,
+	}
+
+	if parsedEventMessage.MediaType != nil {
 		message.MediaType = parsedEventMessage.MediaType.String()
-		message.MediaPath = path
+		message.MediaStatus = model.MediaStatusPending
+		message.RawMessage = parsedEventMessage.Raw
+
+		if parsedEventMessage.Thumbnail != nil {
+			thumbnailPath, err := helper.SaveThumbnail(
+				instance.ID,
+				parsedEventMessage.MessageID,
+				*parsedEventMessage.Thumbnail,
+			)
+			if err != nil {
+				logger.Error("Failed to save thumbnail. ", err)
+			}
+			message.ThumbnailPath = thumbnailPath
+		}
 	}
 
 	err = w.messageService.CreateMessage(&message)
@@ -335,13 +1144,524 @@ func (w *whatsAppService) handleMessage(instanceId string, evt *events.Message)
 		return
 	}
 
-	body := map[string]interface{}{
-		"instanceId": instanceId,
-		"message":    response.NewMessageResponse(message),
+	err = w.webhookService.Send(instanceId, "message", map[string]interface{}{
+		"message": response.NewMessageResponse(message),
+	})
+	if err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+
+	w.autoRead(instance, parsedEventMessage)
+
+	if message.MediaStatus == model.MediaStatusPending {
+		err = queue.NewMediaQueue(w.app).Enqueue(queue.MediaQueueData{
+			InstanceID: instanceId,
+			ID:         message.ID,
+		})
+		if err != nil {
+			logger.Error("Failed to add media to queue. ", err)
+		}
+	}
+}
+
+// handleStatus stores status updates apart from the chat messages.
+func (w *whatsAppService) handleStatus(instance *whatsapp.Instance, parsed whatsapp.Message) {
+	if parsed.Revoked {
+		status, err := w.statusService.RevokeStatus(instance.ID, parsed.SenderJID, parsed.MessageID)
+		if err != nil {
+			logger.Error("Failed to revoke status. ", err)
+			return
+		}
+		if status != nil {
+			w.sendStatusWebhook(instance.ID, "revoke", *status)
+		}
+		return
+	}
+	if parsed.Edited {
+		return
+	}
+
+	status := model.Status{
+		InstanceID:     instance.ID,
+		StatusID:       parsed.MessageID,
+		SenderJID:      parsed.SenderJID,
+		ParticipantJID: parsed.Sender.String(),
+		FromMe:         parsed.FromMe,
+		Timestamp:      parsed.Timestamp,
+		Body:           parsed.Body,
+		Caption:        parsed.Caption,
+	}
+
+	if parsed.MediaType != nil {
+		status.MediaType = parsed.MediaType.String()
+		status.MediaStatus = model.MediaStatusPending
+		status.RawMessage = parsed.Raw
+
+		if parsed.Thumbnail != nil {
+			thumbnailPath, err := helper.SaveThumbnail(instance.ID, "status_"+parsed.MessageID, *parsed.Thumbnail)
+			if err != nil {
+				logger.Error("Failed to save thumbnail. ", err)
+			}
+			status.ThumbnailPath = thumbnailPath
+		}
 	}
 
-	err = http.Request(w.app.Config.WebhookURL, body)
+	if err := w.statusService.CreateStatus(&status); err != nil {
+		logger.Error("Failed to create status. ", err)
+		return
+	}
+	// a redelivered status was already stored and sent
+	if status.ID == 0 {
+		return
+	}
+
+	w.sendStatusWebhook(instance.ID, "new", status)
+
+	if status.MediaStatus == model.MediaStatusPending {
+		err := queue.NewMediaQueue(w.app).Enqueue(queue.MediaQueueData{
+			InstanceID: instance.ID,
+			ID:         status.ID,
+			Status:     true,
+		})
+		if err != nil {
+			logger.Error("Failed to add media to queue. ", err)
+		}
+	}
+}
+
+func (w *whatsAppService) sendStatusWebhook(instanceID string, action string, status model.Status) {
+	err := w.webhookService.Send(instanceID, "status", map[string]interface{}{
+		"action": action,
+		"status": response.NewStatusResponse(status),
+	})
 	if err != nil {
 		logger.Error("Failed to send webhook request. ", err)
 	}
 }
+
+func (w *whatsAppService) autoRead(instance *whatsapp.Instance, message whatsapp.Message) {
+	if message.FromMe {
+		return
+	}
+
+	settings, err := w.settingsService.GetSettings(instance.ID)
+	if err != nil {
+		logger.Error("Failed to get settings. ", err)
+		return
+	}
+
+	if !settings.AutoRead {
+		return
+	}
+
+	err = w.whatsApp.MarkRead(instance, message.MessageID, message.Chat, message.Sender)
+	if err != nil {
+		logger.Error("Failed to mark message as read. ", err)
+		return
+	}
+
+	err = w.chatService.MarkChatRead(instance.ID, message.ChatJID)
+	if err != nil {
+		logger.Error("Failed to mark chat as read. ", err)
+	}
+}
+
+func (w *whatsAppService) ArchiveChat(instance *whatsapp.Instance, jid whatsapp.JID, archive bool) error {
+	last, err := w.lastMessage(instance.ID, jid)
+	if err != nil {
+		return err
+	}
+
+	if err := w.whatsApp.ArchiveChat(instance, jid, archive, last); err != nil {
+		return err
+	}
+	return w.chatService.SetChatArchived(instance.ID, jid, archive)
+}
+
+func (w *whatsAppService) PinChat(instance *whatsapp.Instance, jid whatsapp.JID, pin bool) error {
+	if err := w.whatsApp.PinChat(instance, jid, pin); err != nil {
+		return err
+	}
+	return w.chatService.SetChatPinned(instance.ID, jid, pin)
+}
+
+// MuteChat mutes the chat for the duration, or forever when it is zero.
+func (w *whatsAppService) MuteChat(instance *whatsapp.Instance, jid whatsapp.JID, mute bool, duration time.Duration) error {
+	if err := w.whatsApp.MuteChat(instance, jid, mute, duration); err != nil {
+		return err
+	}
+
+	var mutedUntil *time.Time
+	if mute {
+		until := model.MutedForever
+		if duration > 0 {
+			until = time.Now().Add(duration)
+		}
+		mutedUntil = &until
+	}
+	return w.chatService.SetChatMutedUntil(instance.ID, jid, mutedUntil)
+}
+
+func (w *whatsAppService) MarkChatRead(instance *whatsapp.Instance, jid whatsapp.JID, read bool) error {
+	last, err := w.lastMessage(instance.ID, jid)
+	if err != nil {
+		return err
+	}
+
+	if err := w.whatsApp.MarkChatRead(instance, jid, read, last); err != nil {
+		return err
+	}
+	return w.chatService.SetChatRead(instance.ID, jid, read)
+}
+
+// ClearChat removes every message of the chat, keeping the chat itself.
+func (w *whatsAppService) ClearChat(instance *whatsapp.Instance, jid whatsapp.JID) error {
+	last, err := w.lastMessage(instance.ID, jid)
+	if err != nil {
+		return err
+	}
+
+	if err := w.whatsApp.ClearChat(instance, jid, last); err != nil {
+		return err
+	}
+	return w.messageService.DeleteChatMessages(instance.ID, jid.User)
+}
+
+func (w *whatsAppService) DeleteChat(instance *whatsapp.Instance, jid whatsapp.JID) error {
+	last, err := w.lastMessage(instance.ID, jid)
+	if err != nil {
+		return err
+	}
+
+	if err := w.whatsApp.DeleteChat(instance, jid, last); err != nil {
+		return err
+	}
+	if err := w.messageService.DeleteChatMessages(instance.ID, jid.User); err != nil {
+		return err
+	}
+	return w.chatService.DeleteChat(instance.ID, jid.User)
+}
+
+func (w *whatsAppService) GetBlocklist(instance *whatsapp.Instance) ([]whatsapp.JID, error) {
+	return w.whatsApp.GetBlocklist(instance)
+}
+
+func (w *whatsAppService) UpdateBlocklist(
+	instance *whatsapp.Instance,
+	jid whatsapp.JID,
+	block bool,
+) ([]whatsapp.JID, error) {
+	return w.whatsApp.UpdateBlocklist(instance, jid, block)
+}
+
+func (w *whatsAppService) SendTextStatus(
+	instance *whatsapp.Instance,
+	status whatsapp.TextStatus,
+) (whatsapp.MessageResponse, error) {
+	return w.whatsApp.SendTextStatus(instance, status)
+}
+
+func (w *whatsAppService) SendImageStatus(
+	instance *whatsapp.Instance,
+	imageURL *dataurl.DataURL,
+	mimitype string,
+	caption string,
+) (whatsapp.MessageResponse, error) {
+	return w.whatsApp.SendImageStatus(instance, imageURL, mimitype, caption)
+}
+
+func (w *whatsAppService) SendVideoStatus(
+	instance *whatsapp.Instance,
+	videoURL *dataurl.DataURL,
+	mimitype string,
+	caption string,
+) (whatsapp.MessageResponse, error) {
+	return w.whatsApp.SendVideoStatus(instance, videoURL, mimitype, caption)
+}
+
+func (w *whatsAppService) GetStatusAudience(instance *whatsapp.Instance) (whatsapp.StatusAudience, error) {
+	return w.whatsApp.GetStatusAudience(instance)
+}
+
+// DownloadStatusMedia downloads and stores the media of a status. Statuses
+// vanish after a day, so expired media is not requested again from the phone.
+func (w *whatsAppService) DownloadStatusMedia(instance *whatsapp.Instance, status *model.Status) error {
+	media, err := w.whatsApp.DownloadMedia(instance, status.RawMessage)
+	if err != nil {
+		return err
+	}
+
+	path, err := helper.SaveMedia(instance.ID, "status_"+status.StatusID, media.Data, media.Mimetype)
+	if err != nil {
+		return err
+	}
+
+	err = w.statusService.UpdateStatus(status.ID, map[string]interface{}{
+		"MediaPath":   path,
+		"MediaStatus": model.MediaStatusReady,
+	})
+	if err != nil {
+		return err
+	}
+
+	status.MediaPath = path
+	status.MediaStatus = model.MediaStatusReady
+	w.sendStatusWebhook(instance.ID, "media", *status)
+	return nil
+}
+
+func (w *whatsAppService) FailStatusMedia(instanceID string, status *model.Status) error {
+	err := w.statusService.UpdateStatus(status.ID, map[string]interface{}{
+		"MediaStatus": model.MediaStatusFailed,
+	})
+	if err != nil {
+		return err
+	}
+
+	status.MediaStatus = model.MediaStatusFailed
+	w.sendStatusWebhook(instanceID, "media", *status)
+	return nil
+}
+
+func (w *whatsAppService) CreateNewsletter(
+	instance *whatsapp.Instance,
+	name string,
+	description string,
+	picture []byte,
+) (*whatsapp.Newsletter, error) {
+	return w.whatsApp.CreateNewsletter(instance, name, description, picture)
+}
+
+func (w *whatsAppService) GetNewsletter(instance *whatsapp.Instance, jid whatsapp.JID) (*whatsapp.Newsletter, error) {
+	return w.whatsApp.GetNewsletter(instance, jid)
+}
+
+func (w *whatsAppService) GetNewsletterByInvite(instance *whatsapp.Instance, code string) (*whatsapp.Newsletter, error) {
+	return w.whatsApp.GetNewsletterByInvite(instance, code)
+}
+
+func (w *whatsAppService) GetSubscribedNewsletters(instance *whatsapp.Instance) ([]whatsapp.Newsletter, error) {
+	return w.whatsApp.GetSubscribedNewsletters(instance)
+}
+
+func (w *whatsAppService) FollowNewsletter(instance *whatsapp.Instance, jid whatsapp.JID, follow bool) error {
+	return w.whatsApp.FollowNewsletter(instance, jid, follow)
+}
+
+func (w *whatsAppService) GetNewsletterMessages(
+	instance *whatsapp.Instance,
+	jid whatsapp.JID,
+	count int,
+	before int,
+) ([]whatsapp.NewsletterMessage, error) {
+	return w.whatsApp.GetNewsletterMessages(instance, jid, count, before)
+}
+
+func (w *whatsAppService) SendNewsletterText(
+	instance *whatsapp.Instance,
+	jid whatsapp.JID,
+	text string,
+) (whatsapp.MessageResponse, error) {
+	return w.whatsApp.SendNewsletterText(instance, jid, text)
+}
+
+func (w *whatsAppService) SendNewsletterImage(
+	instance *whatsapp.Instance,
+	jid whatsapp.JID,
+	imageURL *dataurl.DataURL,
+	mimitype string,
+	caption string,
+) (whatsapp.MessageResponse, error) {
+	return w.whatsApp.SendNewsletterImage(instance, jid, imageURL, mimitype, caption)
+}
+
+func (w *whatsAppService) SetPushName(instance *whatsapp.Instance, name string) error {
+	return w.whatsApp.SetPushName(instance, name)
+}
+
+func (w *whatsAppService) SetAbout(instance *whatsapp.Instance, about string) error {
+	return w.whatsApp.SetAbout(instance, about)
+}
+
+func (w *whatsAppService) SetProfilePicture(instance *whatsapp.Instance, picture []byte) (string, error) {
+	return w.whatsApp.SetProfilePicture(instance, picture)
+}
+
+func (w *whatsAppService) GetPrivacySettings(instance *whatsapp.Instance) (whatsapp.PrivacySettings, error) {
+	return w.whatsApp.GetPrivacySettings(instance)
+}
+
+func (w *whatsAppService) SetPrivacySettings(
+	instance *whatsapp.Instance,
+	settings whatsapp.PrivacySettings,
+) (whatsapp.PrivacySettings, error) {
+	return w.whatsApp.SetPrivacySettings(instance, settings)
+}
+
+func (w *whatsAppService) lastMessage(instanceID string, jid whatsapp.JID) (*whatsapp.LastMessage, error) {
+	messages, err := w.messageService.QueryChatMessages(repository.MessageQuery{
+		InstanceID: instanceID,
+		ChatJID:    jid.User,
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*messages) == 0 {
+		return nil, nil
+	}
+
+	message := (*messages)[0]
+	sender, _ := types.ParseJID(message.ParticipantJID)
+	return &whatsapp.LastMessage{
+		ID:        message.MessageID,
+		FromMe:    message.FromMe,
+		Sender:    sender,
+		Timestamp: message.Timestamp,
+	}, nil
+}
+
+func (w *whatsAppService) handleArchive(instanceID string, evt *events.Archive) {
+	err := w.chatService.SetChatArchived(instanceID, evt.JID, evt.Action.GetArchived())
+	if err != nil {
+		logger.Error("Failed to archive chat. ", err)
+		return
+	}
+	w.sendChatWebhook(instanceID, evt.JID, "archive", evt.FromFullSync)
+}
+
+func (w *whatsAppService) handlePin(instanceID string, evt *events.Pin) {
+	err := w.chatService.SetChatPinned(instanceID, evt.JID, evt.Action.GetPinned())
+	if err != nil {
+		logger.Error("Failed to pin chat. ", err)
+		return
+	}
+	w.sendChatWebhook(instanceID, evt.JID, "pin", evt.FromFullSync)
+}
+
+func (w *whatsAppService) handleMute(instanceID string, evt *events.Mute) {
+	var mutedUntil *time.Time
+	if evt.Action.GetMuted() {
+		until := model.MutedForever
+		if end := evt.Action.GetMuteEndTimestamp(); end > 0 {
+			until = time.UnixMilli(end)
+		}
+		mutedUntil = &until
+	}
+
+	err := w.chatService.SetChatMutedUntil(instanceID, evt.JID, mutedUntil)
+	if err != nil {
+		logger.Error("Failed to mute chat. ", err)
+		return
+	}
+	w.sendChatWebhook(instanceID, evt.JID, "mute", evt.FromFullSync)
+}
+
+func (w *whatsAppService) handleMarkChatAsRead(instanceID string, evt *events.MarkChatAsRead) {
+	err := w.chatService.SetChatRead(instanceID, evt.JID, evt.Action.GetRead())
+	if err != nil {
+		logger.Error("Failed to mark chat as read. ", err)
+		return
+	}
+	w.sendChatWebhook(instanceID, evt.JID, "read", evt.FromFullSync)
+}
+
+func (w *whatsAppService) handleClearChat(instanceID string, evt *events.ClearChat) {
+	err := w.messageService.DeleteChatMessages(instanceID, evt.JID.User)
+	if err != nil {
+		logger.Error("Failed to clear chat. ", err)
+		return
+	}
+	w.sendChatWebhook(instanceID, evt.JID, "clear", evt.FromFullSync)
+}
+
+func (w *whatsAppService) handleDeleteChat(instanceID string, evt *events.DeleteChat) {
+	// the webhook carries the chat as it was before the deletion
+	w.sendChatWebhook(instanceID, evt.JID, "delete", evt.FromFullSync)
+
+	err := w.messageService.DeleteChatMessages(instanceID, evt.JID.User)
+	if err != nil {
+		logger.Error("Failed to delete chat messages. ", err)
+		return
+	}
+
+	err = w.chatService.DeleteChat(instanceID, evt.JID.User)
+	if err != nil {
+		logger.Error("Failed to delete chat. ", err)
+	}
+}
+
+// handleBlocklist forwards blocks and unblocks made on any device. A
+// "modify" change carries no details, so the whole blocklist is sent.
+func (w *whatsAppService) handleBlocklist(instanceID string, evt *events.Blocklist) {
+	changes := []map[string]interface{}{}
+	for _, change := range evt.Changes {
+		changes = append(changes, map[string]interface{}{
+			"action":  change.Action,
+			"contact": response.NewBlockedContactResponse(change.JID),
+		})
+	}
+
+	data := map[string]interface{}{
+		"action":  evt.Action,
+		"changes": changes,
+	}
+	if evt.Action == events.BlocklistActionModify {
+		instance := w.app.LoadInstance(instanceID)
+		blocklist, err := w.whatsApp.GetBlocklist(instance)
+		if err != nil {
+			logger.Error("Failed to get blocklist. ", err)
+			return
+		}
+		data["blocklist"] = response.NewBlocklistResponse(blocklist)
+	}
+
+	if err := w.webhookService.Send(instanceID, "blocklist", data); err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+}
+
+func (w *whatsAppService) sendNewsletterWebhook(instanceID string, action string, data map[string]interface{}) {
+	data["action"] = action
+	if err := w.webhookService.Send(instanceID, "newsletter", data); err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+}
+
+// sendChatWebhook reports a chat changed on another device. Changes replayed
+// by a full app state sync are only stored.
+func (w *whatsAppService) sendChatWebhook(instanceID string, jid whatsapp.JID, action string, fromFullSync bool) {
+	if fromFullSync {
+		return
+	}
+
+	chat, err := w.chatService.GetChat(instanceID, jid.User)
+	if err != nil {
+		logger.Error("Failed to get chat. ", err)
+		return
+	}
+	if chat == nil {
+		chat = &model.Chat{
+			InstanceID: instanceID,
+			ChatJID:    jid.User,
+			RemoteJID:  jid.String(),
+		}
+	}
+
+	err = w.webhookService.Send(instanceID, "chat", map[string]interface{}{
+		"action": action,
+		"chat":   response.NewChatResponse(*chat),
+	})
+	if err != nil {
+		logger.Error("Failed to send webhook request. ", err)
+	}
+}
+
+func fileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"sync"
-	"zapmeow/api/model"
+	"syscall"
+	"zapmeow/api/migration"
 	"zapmeow/api/repository"
 	"zapmeow/api/route"
 	"zapmeow/api/service"
 	"zapmeow/config"
 	"zapmeow/docs"
+	"zapmeow/pkg/cluster"
 	"zapmeow/pkg/database"
 	"zapmeow/pkg/logger"
 	"zapmeow/pkg/queue"
@@ -37,22 +44,41 @@ func main() {
 
 	logger.Init()
 
+	database := database.NewDatabase(cfg.DatabaseURL)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(database, os.Args[2:]); err != nil {
+			logger.Fatal("Error running migrations. ", err)
+		}
+		return
+	}
+
+	if cfg.AutoMigrate {
+		if err := database.MigrateUp(migration.Migrations); err != nil {
+			logger.Fatal("Error running migrations. ", err)
+		}
+	}
+	if database.Client().Dialector.Name() == "sqlite" && !database.Client().Migrator().HasTable("messages_fts") {
+		logger.Info("Message search is not indexed, SQLite FTS5 needs the sqlite_fts5 build tag")
+	}
+
 	var instances sync.Map // whatsmeow instances
 	var mutex sync.Mutex
 	var wg sync.WaitGroup
-	wg.Add(1)
 	stopCh := make(chan struct{})
 
-	whatsApp := whatsapp.NewWhatsApp(cfg.DatabaseURL)
+	whatsApp := whatsapp.NewWhatsApp(cfg.WhatsAppDatabaseURL)
 	queue := queue.NewQueue(cfg.RedisAddr, cfg.RedisPassword)
 
-	database := database.NewDatabase(cfg.DatabaseURL)
-	err := database.RunMigrate(
-		&model.Account{},
-		&model.Message{},
-	)
-	if err != nil {
-		logger.Fatal("Error when running gorm automigrate. ", err)
+	// leases live in the same redis as the queues
+	var clusterClient cluster.Cluster
+	if cfg.ClusterMode {
+		clusterClient = cluster.NewCluster(
+			cfg.RedisAddr,
+			cfg.RedisPassword,
+			cfg.NodeID,
+			cfg.NodeAddr,
+			cfg.ClusterLeaseTTL,
+		)
 	}
 
 	app := zapmeow.NewZapMeow(
@@ -68,14 +94,44 @@ func main() {
 	// repository
 	messageRepo := repository.NewMessageRepository(app.Database)
 	accountRepo := repository.NewAccountRepository(app.Database)
+	mediaRepo := repository.NewMediaRepository(app.Database)
+	chatRepo := repository.NewChatRepository(app.Database)
+	contactRepo := repository.NewContactRepository(app.Database)
+	statusRepo := repository.NewStatusRepository(app.Database)
 
 	// service
-	messageService := service.NewMessageService(messageRepo)
-	accountService := service.NewAccountService(accountRepo, messageService)
+	chatService := service.NewChatService(chatRepo)
+	contactService := service.NewContactService(contactRepo)
+	statusService := service.NewStatusService(statusRepo)
+	messageService := service.NewMessageService(messageRepo, chatService)
+	accountService := service.NewAccountService(
+		accountRepo,
+		messageService,
+		chatService,
+		contactService,
+		statusService,
+	)
+	mediaService := service.NewMediaService(app, mediaRepo, messageService)
+	settingsService := service.NewSettingsService(app, accountService)
+	webhookService := service.NewWebhookService(app, settingsService)
+	clusterService := service.NewClusterService(clusterClient)
+	reconnectService := service.NewReconnectService(
+		app,
+		accountService,
+		webhookService,
+		whatsApp,
+	)
 	whatsAppService := service.NewWhatsAppService(
 		app,
 		messageService,
 		accountService,
+		chatService,
+		contactService,
+		statusService,
+		webhookService,
+		settingsService,
+		reconnectService,
+		clusterService,
 		whatsApp,
 	)
 
@@ -84,42 +140,135 @@ func main() {
 		app,
 		messageService,
 		accountService,
+		chatService,
+		contactService,
+		whatsAppService,
+		settingsService,
+	)
+	mediaWorker := worker.NewMediaWorker(
+		app,
+		messageService,
+		statusService,
 		whatsAppService,
 	)
+	mediaRetentionWorker := worker.NewMediaRetentionWorker(
+		app,
+		messageService,
+		accountService,
+		mediaService,
+	)
 
 	r := route.SetupRouter(
 		app,
 		whatsAppService,
 		messageService,
 		accountService,
+		mediaService,
+		settingsService,
+		clusterService,
+		chatService,
+		contactService,
+		statusService,
 	)
 
-	logger.Info("Loading whatsapp instances")
-	accounts, err := accountService.GetConnectedAccounts()
-	if err != nil {
-		logger.Fatal("Error getting accounts. ", err)
-	}
-
-	for _, account := range accounts {
-		logger.Info("Loading instance: ", account.InstanceID)
-		_, err := whatsAppService.GetInstance(account.InstanceID)
+	// in cluster mode the cluster worker claims the instances instead
+	if !cfg.ClusterMode {
+		logger.Info("Loading whatsapp instances")
+		accounts, err := accountService.GetConnectedAccounts()
 		if err != nil {
-			logger.Error("Error getting instance. ", err)
+			logger.Fatal("Error getting accounts. ", err)
+		}
+
+		for _, account := range accounts {
+			logger.Info("Loading instance: ", account.InstanceID)
+			_, err := whatsAppService.GetInstance(account.InstanceID)
+			if err != nil {
+				logger.Error("Error getting instance. ", err)
+			}
 		}
 	}
 
+	server := &http.Server{
+		Addr:    cfg.Port,
+		Handler: r,
+	}
+
 	go func() {
 		fmt.Println("Server is running")
-		if err := r.Run(cfg.Port); err != nil {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal(err)
 		}
 	}()
 
-	if cfg.HistorySync {
-		go historySyncWorker.ProcessQueue()
+	// history sync can be turned on per instance, so the worker always runs
+	app.Wg.Add(3)
+	go historySyncWorker.ProcessQueue()
+	go mediaWorker.ProcessQueue()
+	go mediaRetentionWorker.ProcessRetention()
+
+	if cfg.ClusterMode {
+		clusterWorker := worker.NewClusterWorker(
+			app,
+			clusterClient,
+			accountService,
+			whatsAppService,
+		)
+		app.Wg.Add(1)
+		go clusterWorker.ProcessLeases()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+
+	logger.Info("Shutting down")
+
+	// stopping as the shutdown starts also ends the QR code streams, which
+	// would otherwise keep their connections open; workers finish their
+	// current job before stopping
+	server.RegisterOnShutdown(func() {
+		close(*app.StopCh)
+	})
+
+	// stop accepting requests and let in-flight sends finish
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Error shutting down server. ", err)
 	}
 
-	<-*app.StopCh
-	app.Wg.Wait()
-	close(*app.StopCh)
+	// the disconnected instances stop producing events
+	whatsAppService.DisconnectInstances()
+
+	drained := make(chan struct{})
+	go func() {
+		app.Wg.Wait()
+		webhookService.Wait()
+		close(drained)
+	}()
+
+	// the drain gets its own deadline, the server may have used up the first
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelDrain()
+	select {
+	case <-drained:
+	case <-drainCtx.Done():
+		logger.Error("Shutdown timed out with pending work")
+	}
+
+	if err := whatsApp.Close(); err != nil {
+		logger.Error("Error closing whatsapp store. ", err)
+	}
+	if clusterClient != nil {
+		if err := clusterClient.Close(); err != nil {
+			logger.Error("Error closing cluster. ", err)
+		}
+	}
+	if err := queue.Close(); err != nil {
+		logger.Error("Error closing queue. ", err)
+	}
+	if err := database.Close(); err != nil {
+		logger.Error("Error closing database. ", err)
+	}
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"zapmeow/api/migration"
+	"zapmeow/pkg/database"
+)
+
+// runMigrate handles `zapmeow migrate [up|down [steps]|status]`.
+func runMigrate(db database.Database, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		return db.MigrateUp(migration.Migrations)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		return db.MigrateDown(migration.Migrations, steps)
+	case "status":
+		status, err := db.MigrationStatus(migration.Migrations)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, item := range status {
+			appliedAt := "pending"
+			if item.Applied {
+				appliedAt = item.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", item.Version, item.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("unknown migrate command: %s, use up, down or status", command)
+}
@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Environment = uint
@@ -13,30 +15,111 @@ const (
 	Production
 )
 
+var defaultDocumentMimetypes = []string{
+	"application/pdf",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"application/vnd.oasis.opendocument.text",
+	"application/vnd.oasis.opendocument.spreadsheet",
+	"application/zip",
+	"text/plain",
+	"text/csv",
+}
+
 type Config struct {
 	Environment          Environment
 	StoragePath          string
 	WebhookURL           string
 	DatabaseURL          string
+	WhatsAppDatabaseURL  string
+	AutoMigrate          bool
 	RedisAddr            string
 	RedisPassword        string
 	Port                 string
 	HistorySyncQueueName string
+	MediaQueueName       string
 	HistorySync          bool
+	StrictInstances      bool
+	AutoRead             bool
+	RejectCalls          bool
+	RejectCallMessage    string
 	MaxMessageSync       int
+	MediaRetentionDays   int
+	MediaMaxBytes        int64
+	MediaKeepThumbnails  bool
+	MediaWorkers         int
+	MediaMaxRetries      int
+	ReconnectBaseDelay   time.Duration
+	ReconnectMaxDelay    time.Duration
+	ReconnectMaxAttempts int
+	ShutdownTimeout      time.Duration
+	ClusterMode          bool
+	ClusterForward       bool
+	ClusterLeaseTTL      time.Duration
+	NodeID               string
+	NodeAddr             string
+	MaxImageSize         int64
+	MaxVideoSize         int64
+	MaxAudioSize         int64
+	MaxDocumentSize      int64
+	DocumentMimetypes    []string
 }
 
 func Load() Config {
 	storagePathEnv := os.Getenv("STORAGE_PATH")
 	webhookURLEnv := os.Getenv("WEBHOOK_URL")
-	databaseURLEnv := os.Getenv("DATABASE_PATH")
+	databasePathEnv := os.Getenv("DATABASE_PATH")
+	databaseURLEnv := os.Getenv("DATABASE_URL")
+	whatsAppDatabaseURLEnv := os.Getenv("WHATSAPP_DATABASE_URL")
+	autoMigrateEnv := os.Getenv("AUTO_MIGRATE")
 	redisAddrEnv := os.Getenv("REDIS_ADDR")
 	redisPasswordEnv := os.Getenv("REDIS_PASSWORD")
 	portEnv := os.Getenv("PORT")
 	historySyncEnv := os.Getenv("HISTORY_SYNC")
+	strictInstancesEnv := os.Getenv("STRICT_INSTANCES")
+	autoReadEnv := os.Getenv("AUTO_READ")
+	rejectCallsEnv := os.Getenv("REJECT_CALLS")
+	rejectCallMessageEnv := os.Getenv("REJECT_CALL_MESSAGE")
 	maxMessageSyncEnv := os.Getenv("MAX_MESSAGE_SYNC")
+	mediaRetentionDaysEnv := os.Getenv("MEDIA_RETENTION_DAYS")
+	mediaMaxBytesEnv := os.Getenv("MEDIA_MAX_BYTES")
+	mediaKeepThumbnailsEnv := os.Getenv("MEDIA_KEEP_THUMBNAILS")
+	mediaWorkersEnv := os.Getenv("MEDIA_WORKERS")
+	mediaMaxRetriesEnv := os.Getenv("MEDIA_MAX_RETRIES")
+	reconnectBaseDelayEnv := os.Getenv("RECONNECT_BASE_DELAY")
+	reconnectMaxDelayEnv := os.Getenv("RECONNECT_MAX_DELAY")
+	reconnectMaxAttemptsEnv := os.Getenv("RECONNECT_MAX_ATTEMPTS")
+	shutdownTimeoutEnv := os.Getenv("SHUTDOWN_TIMEOUT")
+	clusterModeEnv := os.Getenv("CLUSTER_MODE")
+	clusterForwardEnv := os.Getenv("CLUSTER_FORWARD")
+	clusterLeaseTTLEnv := os.Getenv("CLUSTER_LEASE_TTL")
+	nodeIDEnv := os.Getenv("NODE_ID")
+	nodeAddrEnv := os.Getenv("NODE_ADDR")
+	maxImageSizeEnv := os.Getenv("MAX_IMAGE_SIZE")
+	maxVideoSizeEnv := os.Getenv("MAX_VIDEO_SIZE")
+	maxAudioSizeEnv := os.Getenv("MAX_AUDIO_SIZE")
+	maxDocumentSizeEnv := os.Getenv("MAX_DOCUMENT_SIZE")
+	documentMimetypesEnv := os.Getenv("DOCUMENT_MIMETYPES")
 	environment := getEnvironment()
 
+	// DATABASE_PATH is the sqlite file from before DSNs were supported
+	if databaseURLEnv == "" {
+		databaseURLEnv = databasePathEnv
+	}
+
+	if whatsAppDatabaseURLEnv == "" {
+		whatsAppDatabaseURLEnv = databaseURLEnv
+	}
+
+	autoMigrate, err := strconv.ParseBool(autoMigrateEnv)
+	if err != nil {
+		autoMigrate = true
+	}
+
 	maxMessageSync, err := strconv.Atoi(maxMessageSyncEnv)
 	if err != nil {
 		maxMessageSync = 10
@@ -47,18 +130,164 @@ func Load() Config {
 		log.Fatal(err)
 	}
 
+	strictInstances, err := strconv.ParseBool(strictInstancesEnv)
+	if err != nil {
+		strictInstances = false
+	}
+
+	autoRead, err := strconv.ParseBool(autoReadEnv)
+	if err != nil {
+		autoRead = false
+	}
+
+	rejectCalls, err := strconv.ParseBool(rejectCallsEnv)
+	if err != nil {
+		rejectCalls = false
+	}
+
+	mediaRetentionDays, err := strconv.Atoi(mediaRetentionDaysEnv)
+	if err != nil {
+		mediaRetentionDays = 0
+	}
+
+	mediaMaxBytes, err := strconv.ParseInt(mediaMaxBytesEnv, 10, 64)
+	if err != nil {
+		mediaMaxBytes = 0
+	}
+
+	mediaKeepThumbnails, err := strconv.ParseBool(mediaKeepThumbnailsEnv)
+	if err != nil {
+		mediaKeepThumbnails = false
+	}
+
+	mediaWorkers, err := strconv.Atoi(mediaWorkersEnv)
+	if err != nil || mediaWorkers < 1 {
+		mediaWorkers = 4
+	}
+
+	mediaMaxRetries, err := strconv.Atoi(mediaMaxRetriesEnv)
+	if err != nil {
+		mediaMaxRetries = 3
+	}
+
+	reconnectBaseDelay, err := strconv.Atoi(reconnectBaseDelayEnv)
+	if err != nil || reconnectBaseDelay < 1 {
+		reconnectBaseDelay = 2
+	}
+
+	reconnectMaxDelay, err := strconv.Atoi(reconnectMaxDelayEnv)
+	if err != nil || reconnectMaxDelay < reconnectBaseDelay {
+		reconnectMaxDelay = 300
+	}
+
+	reconnectMaxAttempts, err := strconv.Atoi(reconnectMaxAttemptsEnv)
+	if err != nil {
+		reconnectMaxAttempts = 0
+	}
+
+	shutdownTimeout, err := strconv.Atoi(shutdownTimeoutEnv)
+	if err != nil || shutdownTimeout < 1 {
+		shutdownTimeout = 30
+	}
+
+	clusterMode, err := strconv.ParseBool(clusterModeEnv)
+	if err != nil {
+		clusterMode = false
+	}
+
+	clusterForward, err := strconv.ParseBool(clusterForwardEnv)
+	if err != nil {
+		clusterForward = true
+	}
+
+	clusterLeaseTTL, err := strconv.Atoi(clusterLeaseTTLEnv)
+	if err != nil || clusterLeaseTTL < 3 {
+		clusterLeaseTTL = 30
+	}
+
+	if nodeIDEnv == "" {
+		nodeIDEnv, _ = os.Hostname()
+	}
+
+	if clusterMode && nodeAddrEnv == "" {
+		log.Fatal("NODE_ADDR is required in cluster mode")
+	}
+
+	maxImageSize, err := strconv.ParseInt(maxImageSizeEnv, 10, 64)
+	if err != nil {
+		maxImageSize = 16 << 20
+	}
+
+	maxVideoSize, err := strconv.ParseInt(maxVideoSizeEnv, 10, 64)
+	if err != nil {
+		maxVideoSize = 64 << 20
+	}
+
+	maxAudioSize, err := strconv.ParseInt(maxAudioSizeEnv, 10, 64)
+	if err != nil {
+		maxAudioSize = 16 << 20
+	}
+
+	maxDocumentSize, err := strconv.ParseInt(maxDocumentSizeEnv, 10, 64)
+	if err != nil {
+		maxDocumentSize = 100 << 20
+	}
+
+	documentMimetypes := splitList(documentMimetypesEnv)
+	if len(documentMimetypes) == 0 {
+		documentMimetypes = defaultDocumentMimetypes
+	}
+
 	return Config{
 		Environment:          environment,
 		StoragePath:          storagePathEnv,
 		WebhookURL:           webhookURLEnv,
 		DatabaseURL:          databaseURLEnv,
+		WhatsAppDatabaseURL:  whatsAppDatabaseURLEnv,
+		AutoMigrate:          autoMigrate,
 		RedisAddr:            redisAddrEnv,
 		RedisPassword:        redisPasswordEnv,
 		Port:                 portEnv,
 		HistorySyncQueueName: "queue:history-sync",
+		MediaQueueName:       "queue:media",
 		HistorySync:          historySync,
+		StrictInstances:      strictInstances,
+		AutoRead:             autoRead,
+		RejectCalls:          rejectCalls,
+		RejectCallMessage:    rejectCallMessageEnv,
 		MaxMessageSync:       maxMessageSync,
+		MediaRetentionDays:   mediaRetentionDays,
+		MediaMaxBytes:        mediaMaxBytes,
+		MediaKeepThumbnails:  mediaKeepThumbnails,
+		MediaWorkers:         mediaWorkers,
+		MediaMaxRetries:      mediaMaxRetries,
+		ReconnectBaseDelay:   time.Duration(reconnectBaseDelay) * time.Second,
+		ReconnectMaxDelay:    time.Duration(reconnectMaxDelay) * time.Second,
+		ReconnectMaxAttempts: reconnectMaxAttempts,
+		ShutdownTimeout:      time.Duration(shutdownTimeout) * time.Second,
+		ClusterMode:          clusterMode,
+		ClusterForward:       clusterForward,
+		ClusterLeaseTTL:      time.Duration(clusterLeaseTTL) * time.Second,
+		NodeID:               nodeIDEnv,
+		NodeAddr:             strings.TrimSuffix(nodeAddrEnv, "/"),
+		MaxImageSize:         maxImageSize,
+		MaxVideoSize:         maxVideoSize,
+		MaxAudioSize:         maxAudioSize,
+		MaxDocumentSize:      maxDocumentSize,
+		DocumentMimetypes:    documentMimetypes,
+	}
+}
+
+// splitList splits a comma separated value, dropping the spaces around each
+// item and the empty ones.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 func getEnvironment() Environment {
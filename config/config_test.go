@@ -0,0 +1,16 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitListTrimsItems(t *testing.T) {
+	got := splitList(" application/pdf, text/plain,, ")
+	if want := []string{"application/pdf", "text/plain"}; !slices.Equal(got, want) {
+		t.Errorf("splitList = %q, want %q", got, want)
+	}
+	if got := splitList(" , "); len(got) != 0 {
+		t.Errorf("splitList of blanks = %q, want none", got)
+	}
+}
@@ -15,9 +15,27 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/{instanceId}/chat/messages": {
+        "/instances": {
+            "get": {
+                "description": "Returns every instance with its status, phone, creation date and whether it is currently connected. In cluster mode, also the node running it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Instances"
+                ],
+                "summary": "List WhatsApp Instances",
+                "responses": {
+                    "200": {
+                        "description": "Instances",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listInstancesResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Returns chat messages from the specified WhatsApp instance.",
+                "description": "Creates a new instance and starts generating QR codes for it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,9 +43,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Chat"
+                    "WhatsApp Instances"
                 ],
-                "summary": "Get WhatsApp Chat Messages",
+                "summary": "Create WhatsApp Instance",
+                "parameters": [
+                    {
+                        "description": "Instance",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createInstanceBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Instance",
+                        "schema": {
+                            "$ref": "#/definitions/handler.createInstanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid instance ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Instance already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/instances/{instanceId}": {
+            "delete": {
+                "description": "Logs out the instance and wipes its device store, messages and media.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Instances"
+                ],
+                "summary": "Delete WhatsApp Instance",
                 "parameters": [
                     {
                         "type": "string",
@@ -35,30 +96,64 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instance deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "description": "Phone",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
+                    "404": {
+                        "description": "Instance not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.getMessagesBody"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
+                }
+            }
+        },
+        "/instances/{instanceId}/restart": {
+            "post": {
+                "description": "Drops the connection of the instance and starts it again from the stored session.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Instances"
+                ],
+                "summary": "Restart WhatsApp Instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of chat messages",
+                        "description": "Instance restarted",
                         "schema": {
-                            "$ref": "#/definitions/handler.getMessagesResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Instance not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/chat/send/audio": {
-            "post": {
-                "description": "Sends an audio message on WhatsApp using the specified instance.",
+        "/{instanceId}/blocklist": {
+            "get": {
+                "description": "Lists the contacts blocked by the instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -66,9 +161,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Chat"
+                    "WhatsApp Contact"
                 ],
-                "summary": "Send Audio Message on WhatsApp",
+                "summary": "Get WhatsApp Blocklist",
                 "parameters": [
                     {
                         "type": "string",
@@ -76,30 +171,27 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Audio message body",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.sendAudioMessageBody"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message Send Response",
+                        "description": "Blocked contacts",
                         "schema": {
-                            "$ref": "#/definitions/handler.sendAudioMessageResponse"
+                            "$ref": "#/definitions/handler.blocklistResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/chat/send/image": {
+        "/{instanceId}/chat/archive": {
             "post": {
-                "description": "Sends an image message on WhatsApp using the specified instance.",
+                "description": "Archives or unarchives a chat on every device of the instance. Archiving also unpins the chat.",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,7 +201,7 @@ const docTemplate = `{
                 "tags": [
                     "WhatsApp Chat"
                 ],
-                "summary": "Send Image Message on WhatsApp",
+                "summary": "Archive WhatsApp Chat",
                 "parameters": [
                     {
                         "type": "string",
@@ -119,28 +211,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Image message body",
+                        "description": "Phone and whether to archive",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.sendImageMessageBody"
+                            "$ref": "#/definitions/handler.archiveChatBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message Send Response",
+                        "description": "Chat",
                         "schema": {
-                            "$ref": "#/definitions/handler.sendImageMessageResponse"
+                            "$ref": "#/definitions/handler.chatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/chat/send/text": {
+        "/{instanceId}/chat/clear": {
             "post": {
-                "description": "Sends a text message on WhatsApp using the specified instance.",
+                "description": "Deletes every message of a chat on every device of the instance, along with the stored messages and media. The chat itself is kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -150,7 +254,7 @@ const docTemplate = `{
                 "tags": [
                     "WhatsApp Chat"
                 ],
-                "summary": "Send Text Message on WhatsApp",
+                "summary": "Clear WhatsApp Chat",
                 "parameters": [
                     {
                         "type": "string",
@@ -160,28 +264,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Text message body",
+                        "description": "Phone",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.sendTextMessageBody"
+                            "$ref": "#/definitions/handler.clearChatBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message Send Response",
+                        "description": "Chat",
                         "schema": {
-                            "$ref": "#/definitions/handler.sendTextMessageResponse"
+                            "$ref": "#/definitions/handler.chatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/check/phones": {
+        "/{instanceId}/chat/delete": {
             "post": {
-                "description": "Verifies if the phone numbers in the provided list are registered WhatsApp users.",
+                "description": "Deletes a chat with its messages on every device of the instance, along with the stored messages and media.",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,9 +305,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Phone Verification"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Check Phones on WhatsApp",
+                "summary": "Delete WhatsApp Chat",
                 "parameters": [
                     {
                         "type": "string",
@@ -201,28 +317,41 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Phone list",
+                        "description": "Phone",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.getCheckPhonesBody"
+                            "$ref": "#/definitions/handler.deleteChatBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of verified numbers",
+                        "description": "Chat deleted",
                         "schema": {
-                            "$ref": "#/definitions/handler.getCheckPhonesResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/contact/info": {
-            "get": {
-                "description": "Retrieves contact information.",
+        "/{instanceId}/chat/messages": {
+            "post": {
+                "description": "Returns chat messages from the specified WhatsApp instance, newest first.\nPages with a limit and a before or after cursor, which is a cursor returned for the same chat, a message ID or an RFC 3339 timestamp.\nA cursor of another chat is rejected.\nWithout a limit every matching message is returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -230,9 +359,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Contact"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Get Contact Information",
+                "summary": "Get WhatsApp Chat Messages",
                 "parameters": [
                     {
                         "type": "string",
@@ -242,26 +371,34 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Phone",
-                        "name": "phone",
-                        "in": "query",
-                        "required": true
+                        "description": "Phone, cursor and filters",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.getMessagesBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Contact Information",
+                        "description": "List of chat messages",
                         "schema": {
-                            "$ref": "#/definitions/handler.contactInfoResponse"
+                            "$ref": "#/definitions/handler.getMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/logout": {
+        "/{instanceId}/chat/mute": {
             "post": {
-                "description": "Logs out from the specified WhatsApp instance.",
+                "description": "Mutes a chat for a duration in seconds, forever when the duration is zero, or unmutes it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -269,9 +406,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Logout"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Logout from WhatsApp",
+                "summary": "Mute WhatsApp Chat",
                 "parameters": [
                     {
                         "type": "string",
@@ -279,22 +416,42 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Phone, whether to mute and the duration in seconds",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.muteChatBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "Chat",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handler.chatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/profile": {
-            "get": {
-                "description": "Retrieves profile information.",
+        "/{instanceId}/chat/pin": {
+            "post": {
+                "description": "Pins or unpins a chat on every device of the instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -302,9 +459,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Profile"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Get Profile Information",
+                "summary": "Pin WhatsApp Chat",
                 "parameters": [
                     {
                         "type": "string",
@@ -312,28 +469,52 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Phone and whether to pin",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.pinChatBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile Information",
+                        "description": "Chat",
                         "schema": {
-                            "$ref": "#/definitions/handler.getProfileInfoResponse"
+                            "$ref": "#/definitions/handler.chatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/qrcode": {
-            "get": {
-                "description": "Returns a QR code to initiate WhatsApp login.",
+        "/{instanceId}/chat/read": {
+            "post": {
+                "description": "Marks a whole chat as read, or as unread, on every device of the instance.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Login"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Get WhatsApp QR Code",
+                "summary": "Mark WhatsApp Chat Read",
                 "parameters": [
                     {
                         "type": "string",
@@ -341,21 +522,42 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Phone and whether the chat is read",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.markChatReadBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "QR Code",
+                        "description": "Chat",
                         "schema": {
-                            "$ref": "#/definitions/handler.getQrCodeResponse"
+                            "$ref": "#/definitions/handler.chatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/{instanceId}/status": {
-            "get": {
-                "description": "Returns the status of the specified WhatsApp instance.",
+        "/{instanceId}/chat/send/audio": {
+            "post": {
+                "description": "Sends an audio message on WhatsApp using the specified instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -363,9 +565,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Status"
+                    "WhatsApp Chat"
                 ],
-                "summary": "Get WhatsApp Instance Status",
+                "summary": "Send Audio Message on WhatsApp",
                 "parameters": [
                     {
                         "type": "string",
@@ -373,157 +575,3053 @@ const docTemplate = `{
                         "name": "instanceId",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Status Response",
-                        "schema": {
-                            "$ref": "#/definitions/handler.getStatusResponse"
+                    },
+                    {
+                        "description": "Audio message body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendAudioMessageBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message Send Response",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendAudioMessageResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/chat/send/document": {
+            "post": {
+                "description": "Sends an Document message on WhatsApp using the specified instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Chat"
+                ],
+                "summary": "Send Document Message on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Document message body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendDocumentMessageBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message Send Response",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendDocumentMessageResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/chat/send/image": {
+            "post": {
+                "description": "Sends an image message on WhatsApp using the specified instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Chat"
+                ],
+                "summary": "Send Image Message on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image message body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendImageMessageBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message Send Response",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendImageMessageResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/chat/send/text": {
+            "post": {
+                "description": "Sends a text message on WhatsApp using the specified instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Chat"
+                ],
+                "summary": "Send Text Message on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text message body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendTextMessageBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message Send Response",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendTextMessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/chats": {
+            "get": {
+                "description": "Returns the chats of the specified instance with their last message time, unread count and name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Chat"
+                ],
+                "summary": "Get WhatsApp Chats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "last_message (default), name or unread",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only archived or only unarchived chats",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum chats, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Chats to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of chats",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getChatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/check/phones": {
+            "post": {
+                "description": "Verifies if the phone numbers in the provided list are registered WhatsApp users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Phone Verification"
+                ],
+                "summary": "Check Phones on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Phone list",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.getCheckPhonesBody"
                         }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of verified numbers",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getCheckPhonesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/contact/block": {
+            "post": {
+                "description": "Blocks or unblocks a phone or JID and returns the updated blocklist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Contact"
+                ],
+                "summary": "Block WhatsApp Contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Phone and whether to block",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.blockContactBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocked contacts",
+                        "schema": {
+                            "$ref": "#/definitions/handler.blocklistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/contact/info": {
+            "get": {
+                "description": "Retrieves contact information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Contact"
+                ],
+                "summary": "Get Contact Information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Contact Information",
+                        "schema": {
+                            "$ref": "#/definitions/handler.contactInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/contacts": {
+            "get": {
+                "description": "Returns the stored contacts of the specified instance, saved contacts first by their address book name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Contact"
+                ],
+                "summary": "Get WhatsApp Contacts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text found in any name or the phone",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum contacts, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Contacts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of contacts",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getContactsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/contacts/info": {
+            "post": {
+                "description": "Looks up the name, status and profile picture ID of up to 100 phones in one request.\nWith download_pictures, profile pictures are saved and returned inline. A picture is only downloaded again after it changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Contact"
+                ],
+                "summary": "Get Contacts Information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Phone list",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.getContactsInfoBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Contacts Information",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getContactsInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/logout": {
+            "post": {
+                "description": "Logs out from the specified WhatsApp instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Logout"
+                ],
+                "summary": "Logout from WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/media/download": {
+            "post": {
+                "description": "Downloads the media of a stored message right away, instead of waiting for the media queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Media"
+                ],
+                "summary": "Download Message Media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.downloadMediaBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message with its media",
+                        "schema": {
+                            "$ref": "#/definitions/handler.downloadMediaResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Media expired on the CDN, a re-upload was requested from the phone",
+                        "schema": {
+                            "$ref": "#/definitions/handler.downloadMediaResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/media/retention": {
+            "get": {
+                "description": "Returns the media retention policies configured for the specified instance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Media"
+                ],
+                "summary": "Get Media Retention Policies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Media retention policies",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getMediaRetentionResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces the retention policy of a media type for the specified instance. An empty media type applies to all media, and max bytes is only enforced on that policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Media"
+                ],
+                "summary": "Update Media Retention Policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Retention policy",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.updateMediaRetentionBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Media retention policy",
+                        "schema": {
+                            "$ref": "#/definitions/handler.updateMediaRetentionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/media/retry": {
+            "post": {
+                "description": "Asks the phone to re-upload the media of a stored message that is no longer on the WhatsApp CDN. The media webhook is sent once the download completes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Media"
+                ],
+                "summary": "Request Media Re-upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.retryMediaBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Re-upload requested",
+                        "schema": {
+                            "$ref": "#/definitions/handler.retryMediaResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/messages/search": {
+            "get": {
+                "description": "Finds messages whose text or caption contains every word of the query, best matches first, with the matched words wrapped in \u003cmark\u003e tags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Chat"
+                ],
+                "summary": "Search WhatsApp Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Words to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chat phone",
+                        "name": "chat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender phone",
+                        "name": "sender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start date",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end date",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum matches, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Matches to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching messages",
+                        "schema": {
+                            "$ref": "#/definitions/handler.searchMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletter/follow": {
+            "post": {
+                "description": "Follows or unfollows a channel found by its ID or by its invite code or link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Follow WhatsApp Newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Newsletter and whether to follow it",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.followNewsletterBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Newsletter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.newsletterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletter/info": {
+            "get": {
+                "description": "Returns a channel found by its ID or by its invite code or link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Get WhatsApp Newsletter Info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invite code or link, used without an ID",
+                        "name": "invite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Newsletter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.newsletterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletter/messages": {
+            "get": {
+                "description": "Fetches the posts of a channel from WhatsApp, paging back with the before cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Get WhatsApp Newsletter Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Server ID of the post to page back from",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum posts, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getNewsletterMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletter/send/image": {
+            "post": {
+                "description": "Posts an image with an optional caption to a channel the instance owns or administers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Send Image to WhatsApp Newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Newsletter ID, image and caption",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendNewsletterImageBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posted message",
+                        "schema": {
+                            "$ref": "#/definitions/handler.newsletterMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletter/send/text": {
+            "post": {
+                "description": "Posts a text to a channel the instance owns or administers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Send Text to WhatsApp Newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Newsletter ID and text",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendNewsletterTextBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posted message",
+                        "schema": {
+                            "$ref": "#/definitions/handler.newsletterMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/newsletters": {
+            "get": {
+                "description": "Lists the channels the instance follows or owns.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Get WhatsApp Newsletters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of newsletters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getNewslettersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a channel owned by the instance, accepting the channel terms of WhatsApp on its behalf.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Newsletter"
+                ],
+                "summary": "Create WhatsApp Newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name, description and picture",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createNewsletterBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created newsletter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.newsletterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/pair": {
+            "post": {
+                "description": "Returns an 8-character code to link the instance from the phone, as an alternative to scanning the QR code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Login"
+                ],
+                "summary": "Pair WhatsApp with a Phone Number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Phone",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.pairPhoneBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pairing code",
+                        "schema": {
+                            "$ref": "#/definitions/handler.pairPhoneResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/profile": {
+            "get": {
+                "description": "Retrieves profile information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Get Profile Information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile Information",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getProfileInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/profile/about": {
+            "post": {
+                "description": "Changes the about text of the instance profile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Set Profile About",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "About text",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfileAboutBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "About text",
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfileAboutBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/profile/name": {
+            "post": {
+                "description": "Changes the name the instance shows to other users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Set Profile Name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfileNameBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Name",
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfileNameBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/profile/picture": {
+            "post": {
+                "description": "Changes the profile picture of the instance, or removes it when base64 is empty.\nThe picture is cropped to the given area, or to the largest centered square, and scaled down to 640 pixels.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Set Profile Picture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Picture and crop area",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfilePictureBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New picture ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.setProfilePictureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/profile/privacy": {
+            "get": {
+                "description": "Returns who can see the last seen, online status, profile photo and about, whether read receipts are sent, and who can add the instance to groups or call it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Get Privacy Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Privacy settings",
+                        "schema": {
+                            "$ref": "#/definitions/handler.privacySettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Changes the given privacy settings and leaves the omitted ones as they are.\nlast_seen, profile, status and group_add take all, contacts, contact_blacklist or none.\nonline takes all or match_last_seen, read_receipts all or none, and call_add all or known.\nstatus is who can see the about text, not who receives status updates, see the status audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Profile"
+                ],
+                "summary": "Set Privacy Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Privacy settings",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/whatsapp.PrivacySettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Privacy settings",
+                        "schema": {
+                            "$ref": "#/definitions/handler.privacySettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/qrcode": {
+            "get": {
+                "description": "Returns a QR code to initiate WhatsApp login, optionally rendered as an image.",
+                "produces": [
+                    "application/json",
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "WhatsApp Login"
+                ],
+                "summary": "Get WhatsApp QR Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg",
+                            "base64"
+                        ],
+                        "type": "string",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR Code",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getQrCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "No QR code available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/qrcode/stream": {
+            "get": {
+                "description": "Pushes every new QR code as a Server-Sent Event (\"code\", with the raw code and a base64 PNG), then the pairing result (\"paired\", \"connected\", \"timeout\", \"rate-limit\" or \"error\"). The stream ends once the login succeeds or fails.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "WhatsApp Login"
+                ],
+                "summary": "Stream WhatsApp QR Codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR Code events",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getQrCodeResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/settings": {
+            "get": {
+                "description": "Returns the metadata and setting overrides of the specified instance, along with the effective settings after falling back to the global config.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Instances"
+                ],
+                "summary": "Get Instance Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instance settings",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getSettingsResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the metadata and setting overrides of the specified instance. A null setting falls back to the global config. Changes apply to the next event, without restarting the instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Instances"
+                ],
+                "summary": "Update Instance Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Instance settings",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.updateSettingsBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instance settings",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getSettingsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/status": {
+            "get": {
+                "description": "Returns the status of the specified WhatsApp instance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Get WhatsApp Instance Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status Response",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/status/audience": {
+            "get": {
+                "description": "Returns who receives the status updates posted by the instance.\nThe audience is read-only: it follows the status privacy of the account, which can only be changed on the phone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Get WhatsApp Status Audience",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status audience",
+                        "schema": {
+                            "$ref": "#/definitions/handler.statusAudienceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/status/image": {
+            "post": {
+                "description": "Posts an image status update with an optional caption.\nIt reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Post Image Status on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image status body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendImageStatusBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posted status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendStatusResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/status/text": {
+            "post": {
+                "description": "Posts a text status update over a solid background.\nIt reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Post Text Status on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text, colors and font",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendTextStatusBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posted status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/status/video": {
+            "post": {
+                "description": "Posts a video status update with an optional caption.\nIt reaches the audience set in the status privacy of the account, which can't be chosen per update or changed through the API, see /status/audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Post Video Status on WhatsApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Video status body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendVideoStatusBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posted status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.sendStatusResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Media exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Media content does not match its type, or the type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/{instanceId}/statuses": {
+            "get": {
+                "description": "Returns the stored status updates of the specified instance, newest first.\nThey hold both the updates of contacts and the ones the instance posted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Status"
+                ],
+                "summary": "Get WhatsApp Statuses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instance ID",
+                        "name": "instanceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone of the contact who posted",
+                        "name": "sender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only the updates posted by the instance, or only the others",
+                        "name": "from_me",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp of the oldest update",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum updates, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Updates to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of status updates",
+                        "schema": {
+                            "$ref": "#/definitions/handler.getStatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.archiveChatBody": {
+            "type": "object",
+            "properties": {
+                "archive": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.blockContactBody": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.blocklistResponse": {
+            "type": "object",
+            "properties": {
+                "blocklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BlockedContact"
+                    }
+                }
+            }
+        },
+        "handler.chatResponse": {
+            "type": "object",
+            "properties": {
+                "chat": {
+                    "$ref": "#/definitions/response.Chat"
+                }
+            }
+        },
+        "handler.clearChatBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.contactInfoResponse": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "$ref": "#/definitions/whatsapp.ContactInfo"
+                }
+            }
+        },
+        "handler.contactInfoResult": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/response.ContactInfo"
+                },
+                "found": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.createInstanceBody": {
+            "type": "object",
+            "required": [
+                "instance_id"
+            ],
+            "properties": {
+                "instance_id": {
+                    "description": "InstanceID has 1 to 64 letters, digits, \"-\" or \"_\"",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.createInstanceResponse": {
+            "type": "object",
+            "properties": {
+                "instance": {
+                    "$ref": "#/definitions/response.Instance"
+                }
+            }
+        },
+        "handler.createNewsletterBody": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "picture": {
+                    "description": "Picture is an optional base64 image, cropped to a centered square",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.deleteChatBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.downloadMediaBody": {
+            "type": "object",
+            "properties": {
+                "message_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.downloadMediaResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.effectiveSettings": {
+            "type": "object",
+            "properties": {
+                "auto_read": {
+                    "type": "boolean"
+                },
+                "history_sync": {
+                    "type": "boolean"
+                },
+                "max_message_sync": {
+                    "type": "integer"
+                },
+                "reject_call_message": {
+                    "type": "string"
+                },
+                "reject_calls": {
+                    "type": "boolean"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.followNewsletterBody": {
+            "type": "object",
+            "properties": {
+                "follow": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invite": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.getChatsResponse": {
+            "type": "object",
+            "properties": {
+                "chats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Chat"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.getCheckPhonesBody": {
+            "type": "object",
+            "properties": {
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.getCheckPhonesResponse": {
+            "type": "object",
+            "properties": {
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/whatsapp.IsOnWhatsAppResponse"
+                    }
+                }
+            }
+        },
+        "handler.getContactsInfoBody": {
+            "type": "object",
+            "properties": {
+                "download_pictures": {
+                    "type": "boolean"
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.getContactsInfoResponse": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.contactInfoResult"
+                    }
+                }
+            }
+        },
+        "handler.getContactsResponse": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Contact"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.getMediaRetentionResponse": {
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MediaRetentionPolicy"
+                    }
+                }
+            }
+        },
+        "handler.getMessagesBody": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "description": "Before and After take a cursor of a previous page of the same chat, a\nmessage ID or an RFC 3339 timestamp",
+                    "type": "string"
+                },
+                "from_me": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "metadata_only": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.getMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "description": "cursors for the older and newer pages around this one",
+                    "type": "string"
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Message"
+                    }
+                }
+            }
+        },
+        "handler.getNewsletterMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "description": "cursor for the older page",
+                    "type": "integer"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NewsletterMessage"
+                    }
+                }
+            }
+        },
+        "handler.getNewslettersResponse": {
+            "type": "object",
+            "properties": {
+                "newsletters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Newsletter"
+                    }
+                }
+            }
+        },
+        "handler.getProfileInfoResponse": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "$ref": "#/definitions/whatsapp.ContactInfo"
+                }
+            }
+        },
+        "handler.getQrCodeResponse": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "qrcode": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.getSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "effective": {
+                    "$ref": "#/definitions/handler.effectiveSettings"
+                },
+                "settings": {
+                    "$ref": "#/definitions/response.InstanceSettings"
+                }
+            }
+        },
+        "handler.getStatusResponse": {
+            "type": "object",
+            "properties": {
+                "disconnect_reason": {
+                    "type": "string"
+                },
+                "reconnect_attempts": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.getStatusesResponse": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Status"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.listInstancesResponse": {
+            "type": "object",
+            "properties": {
+                "instances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Instance"
+                    }
+                }
+            }
+        },
+        "handler.markChatReadBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.muteChatBody": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "mute": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.newsletterMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.NewsletterMessage"
+                }
+            }
+        },
+        "handler.newsletterResponse": {
+            "type": "object",
+            "properties": {
+                "newsletter": {
+                    "$ref": "#/definitions/response.Newsletter"
+                }
+            }
+        },
+        "handler.pairPhoneBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.pairPhoneResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.pinChatBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                },
+                "pin": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.privacySettingsResponse": {
+            "type": "object",
+            "properties": {
+                "privacy": {
+                    "$ref": "#/definitions/whatsapp.PrivacySettings"
+                }
+            }
+        },
+        "handler.profilePictureCrop": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                },
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.retryMediaBody": {
+            "type": "object",
+            "properties": {
+                "message_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.retryMediaResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.searchMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MessageMatch"
+                    }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.contactInfoResponse": {
+        },
+        "handler.sendAudioMessageBody": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/whatsapp.ContactInfo"
+                "base64": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
                 }
             }
         },
-        "handler.getCheckPhonesBody": {
+        "handler.sendAudioMessageResponse": {
             "type": "object",
             "properties": {
-                "phones": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.sendDocumentMessageBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendDocumentMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.sendImageMessageBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendImageMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.sendImageStatusBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendNewsletterImageBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendNewsletterTextBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendStatusResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/response.Status"
+                }
+            }
+        },
+        "handler.sendTextMessageBody": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendTextMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/response.Message"
+                }
+            }
+        },
+        "handler.sendTextStatusBody": {
+            "type": "object",
+            "properties": {
+                "background_color": {
+                    "description": "colors are \"#RRGGBB\"; the background defaults to black and the text to white",
+                    "type": "string"
+                },
+                "font": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "text_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.sendVideoStatusBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.setProfileAboutBody": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.setProfileNameBody": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.setProfilePictureBody": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "description": "Base64 is a data URL of the picture, empty to remove it",
+                    "type": "string"
+                },
+                "crop": {
+                    "$ref": "#/definitions/handler.profilePictureCrop"
+                }
+            }
+        },
+        "handler.setProfilePictureResponse": {
+            "type": "object",
+            "properties": {
+                "picture_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.statusAudienceResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "type": {
+                    "description": "Type is \"contacts\", \"blacklist\" (all contacts except the list) or\n\"whitelist\" (only the list)",
+                    "type": "string"
                 }
             }
         },
-        "handler.getCheckPhonesResponse": {
+        "handler.updateMediaRetentionBody": {
             "type": "object",
             "properties": {
-                "phones": {
+                "keep_thumbnail": {
+                    "type": "boolean"
+                },
+                "max_age_days": {
+                    "type": "integer"
+                },
+                "max_bytes": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.updateMediaRetentionResponse": {
+            "type": "object",
+            "properties": {
+                "policy": {
+                    "$ref": "#/definitions/response.MediaRetentionPolicy"
+                }
+            }
+        },
+        "handler.updateSettingsBody": {
+            "type": "object",
+            "properties": {
+                "auto_read": {
+                    "type": "boolean"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "history_sync": {
+                    "type": "boolean"
+                },
+                "max_message_sync": {
+                    "type": "integer"
+                },
+                "reject_call_message": {
+                    "type": "string"
+                },
+                "reject_calls": {
+                    "type": "boolean"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/whatsapp.IsOnWhatsAppResponse"
+                        "type": "string"
                     }
+                },
+                "webhook_url": {
+                    "type": "string"
                 }
             }
         },
-        "handler.getMessagesBody": {
+        "response.BlockedContact": {
             "type": "object",
             "properties": {
+                "jid": {
+                    "type": "string"
+                },
                 "phone": {
                     "type": "string"
                 }
             }
         },
-        "handler.getMessagesResponse": {
+        "response.Chat": {
             "type": "object",
             "properties": {
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.Message"
-                    }
+                "archived": {
+                    "type": "boolean"
+                },
+                "chat": {
+                    "type": "string"
+                },
+                "jid": {
+                    "type": "string"
+                },
+                "last_message_at": {
+                    "type": "string"
+                },
+                "marked_unread": {
+                    "type": "boolean"
+                },
+                "muted_until": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pinned": {
+                    "type": "boolean"
+                },
+                "unread_count": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.getProfileInfoResponse": {
+        "response.Contact": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/whatsapp.ContactInfo"
+                "business_name": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "jid": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "picture_id": {
+                    "type": "string"
+                },
+                "picture_updated_at": {
+                    "type": "string"
+                },
+                "picture_url": {
+                    "type": "string"
+                },
+                "push_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "handler.getQrCodeResponse": {
+        "response.ContactInfo": {
             "type": "object",
             "properties": {
-                "qrcode": {
+                "business_name": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "jid": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "picture_base64": {
+                    "type": "string"
+                },
+                "picture_id": {
+                    "type": "string"
+                },
+                "picture_updated_at": {
+                    "type": "string"
+                },
+                "picture_url": {
+                    "type": "string"
+                },
+                "push_name": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "handler.getStatusResponse": {
+        "response.Error": {
             "type": "object",
             "properties": {
-                "status": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 }
             }
         },
-        "handler.sendAudioMessageBody": {
+        "response.Instance": {
             "type": "object",
             "properties": {
-                "base64": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "disconnect_reason": {
+                    "type": "string"
+                },
+                "instance_id": {
+                    "type": "string"
+                },
+                "node": {
                     "type": "string"
                 },
                 "phone": {
                     "type": "string"
+                },
+                "reconnect_attempts": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "handler.sendAudioMessageResponse": {
+        "response.InstanceSettings": {
             "type": "object",
             "properties": {
-                "message": {
-                    "$ref": "#/definitions/response.Message"
+                "auto_read": {
+                    "type": "boolean"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "history_sync": {
+                    "type": "boolean"
+                },
+                "max_message_sync": {
+                    "type": "integer"
+                },
+                "reject_call_message": {
+                    "type": "string"
+                },
+                "reject_calls": {
+                    "type": "boolean"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "webhook_url": {
+                    "type": "string"
                 }
             }
         },
-        "handler.sendImageMessageBody": {
+        "response.MediaRetentionPolicy": {
             "type": "object",
             "properties": {
-                "base64": {
+                "keep_thumbnail": {
+                    "type": "boolean"
+                },
+                "max_age_days": {
+                    "type": "integer"
+                },
+                "max_bytes": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Message": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
                 },
-                "phone": {
+                "caption": {
+                    "type": "string"
+                },
+                "chat": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "from_me": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "media_base64": {
+                    "type": "string"
+                },
+                "media_mimetype": {
+                    "type": "string"
+                },
+                "media_status": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "thumbnail_base64": {
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "handler.sendImageMessageResponse": {
+        "response.MessageMatch": {
             "type": "object",
             "properties": {
                 "message": {
                     "$ref": "#/definitions/response.Message"
+                },
+                "snippet": {
+                    "type": "string"
                 }
             }
         },
-        "handler.sendTextMessageBody": {
+        "response.Newsletter": {
             "type": "object",
             "properties": {
-                "phone": {
+                "created_at": {
                     "type": "string"
                 },
-                "text": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invite": {
+                    "type": "string"
+                },
+                "muted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "picture_url": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "state": {
                     "type": "string"
+                },
+                "subscribers": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "type": "boolean"
                 }
             }
         },
-        "handler.sendTextMessageResponse": {
+        "response.NewsletterMessage": {
             "type": "object",
             "properties": {
-                "message": {
-                    "$ref": "#/definitions/response.Message"
+                "body": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "server_id": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "response.Message": {
+        "response.Status": {
             "type": "object",
             "properties": {
                 "body": {
                     "type": "string"
                 },
-                "chat": {
+                "caption": {
                     "type": "string"
                 },
                 "from_me": {
@@ -538,15 +3636,24 @@ const docTemplate = `{
                 "media_mimetype": {
                     "type": "string"
                 },
-                "media_type": {
+                "media_status": {
                     "type": "string"
                 },
-                "message_id": {
+                "media_type": {
                     "type": "string"
                 },
+                "revoked": {
+                    "type": "boolean"
+                },
                 "sender": {
                     "type": "string"
                 },
+                "status_id": {
+                    "type": "string"
+                },
+                "thumbnail_base64": {
+                    "type": "string"
+                },
                 "timestamp": {
                     "type": "string"
                 }
@@ -582,6 +3689,32 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "whatsapp.PrivacySettings": {
+            "type": "object",
+            "properties": {
+                "call_add": {
+                    "type": "string"
+                },
+                "group_add": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "online": {
+                    "type": "string"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "read_receipts": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
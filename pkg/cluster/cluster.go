@@ -0,0 +1,180 @@
+package cluster
+
+import (
+	"errors"
+	"time"
+	"zapmeow/pkg/logger"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	leaseKeyPrefix = "cluster:lease:"
+	nodeKeyPrefix  = "cluster:node:"
+)
+
+// renewScript extends a lease only while it is still held by the node.
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript drops a lease only while it is still held by the node.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+var ErrLeaseHeld = errors.New("lease is held by another node")
+
+type Node struct {
+	ID   string
+	Addr string
+}
+
+type Cluster interface {
+	NodeID() string
+	Heartbeat() error
+	Nodes() ([]Node, error)
+	Acquire(instanceID string) error
+	Renew(instanceID string) (bool, error)
+	Release(instanceID string) error
+	Owner(instanceID string) (*Node, error)
+	Close() error
+}
+
+type cluster struct {
+	client   *redis.Client
+	node     Node
+	leaseTTL time.Duration
+}
+
+func NewCluster(addr string, password string, nodeID string, nodeAddr string, leaseTTL time.Duration) *cluster {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       0,
+	})
+	if _, err := client.Ping().Result(); err != nil {
+		logger.Fatal(err)
+	}
+	return &cluster{
+		client: client,
+		node: Node{
+			ID:   nodeID,
+			Addr: nodeAddr,
+		},
+		leaseTTL: leaseTTL,
+	}
+}
+
+func (c *cluster) NodeID() string {
+	return c.node.ID
+}
+
+// Heartbeat announces the node and its address. A node that stops beating
+// disappears from Nodes once its key expires, and so do its leases.
+func (c *cluster) Heartbeat() error {
+	return c.client.Set(nodeKeyPrefix+c.node.ID, c.node.Addr, c.leaseTTL).Err()
+}
+
+func (c *cluster) Nodes() ([]Node, error) {
+	var nodes []Node
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(cursor, nodeKeyPrefix+"*", 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			addr, err := c.client.Get(key).Result()
+			if err == redis.Nil {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, Node{
+				ID:   key[len(nodeKeyPrefix):],
+				Addr: addr,
+			})
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nodes, nil
+		}
+	}
+}
+
+func (c *cluster) Acquire(instanceID string) error {
+	ok, err := c.client.SetNX(leaseKeyPrefix+instanceID, c.node.ID, c.leaseTTL).Result()
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	renewed, err := c.Renew(instanceID)
+	if err != nil {
+		return err
+	}
+	if !renewed {
+		return ErrLeaseHeld
+	}
+	return nil
+}
+
+// Renew reports false when the lease expired and was taken by another node.
+func (c *cluster) Renew(instanceID string) (bool, error) {
+	result, err := renewScript.Run(
+		c.client,
+		[]string{leaseKeyPrefix + instanceID},
+		c.node.ID,
+		c.leaseTTL.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+func (c *cluster) Release(instanceID string) error {
+	return releaseScript.Run(
+		c.client,
+		[]string{leaseKeyPrefix + instanceID},
+		c.node.ID,
+	).Err()
+}
+
+// Owner returns nil when nobody holds the lease of the instance.
+func (c *cluster) Owner(instanceID string) (*Node, error) {
+	nodeID, err := c.client.Get(leaseKeyPrefix + instanceID).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if nodeID == c.node.ID {
+		return &c.node, nil
+	}
+
+	addr, err := c.client.Get(nodeKeyPrefix + nodeID).Result()
+	if err == redis.Nil {
+		// the node is gone, its lease is about to expire
+		return &Node{ID: nodeID}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &Node{ID: nodeID, Addr: addr}, nil
+}
+
+func (c *cluster) Close() error {
+	return c.client.Close()
+}
@@ -3,22 +3,39 @@ package database
 import (
 	"zapmeow/pkg/logger"
 
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
 )
 
 type Database interface {
-	RunMigrate(dst ...interface{}) error
+	MigrateUp(migrations []Migration) error
+	MigrateDown(migrations []Migration, steps int) error
+	MigrationStatus(migrations []Migration) ([]MigrationStatus, error)
 	Client() *gorm.DB
+	Close() error
 }
 
 type database struct {
 	client *gorm.DB
 }
 
-func NewDatabase(databasePath string) *database {
-	client, err := gorm.Open(sqlite.Open(databasePath), &gorm.Config{
+func NewDatabase(dsn string) *database {
+	driver, source, err := ParseDSN(dsn)
+	if err != nil {
+		logger.Fatal("Error parsing database dsn. ", err)
+	}
+
+	var dialector gorm.Dialector
+	switch driver {
+	case Postgres:
+		dialector = postgres.Open(source)
+	default:
+		dialector = sqlite.Open(source)
+	}
+
+	client, err := gorm.Open(dialector, &gorm.Config{
 		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
 	})
 	if err != nil {
@@ -30,10 +47,14 @@ func NewDatabase(databasePath string) *database {
 	}
 }
 
-func (d *database) RunMigrate(dst ...interface{}) error {
-	return d.client.AutoMigrate(dst...)
-}
-
 func (d *database) Client() *gorm.DB {
 	return d.client
 }
+
+func (d *database) Close() error {
+	db, err := d.client.DB()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestWithSQLiteParamsMergesTheQuery(t *testing.T) {
+	params := url.Values{"_foreign_keys": {"on"}}
+	for source, want := range map[string]string{
+		"zapmeow.db":                      "zapmeow.db?_foreign_keys=on",
+		"zapmeow.db?_busy_timeout=5000":   "zapmeow.db?_busy_timeout=5000&_foreign_keys=on",
+		"zapmeow.db?_foreign_keys=off":    "zapmeow.db?_foreign_keys=on",
+		"/data/zap meow.db?cache=shared&": "/data/zap meow.db?_foreign_keys=on&cache=shared",
+	} {
+		got, err := WithSQLiteParams(source, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("WithSQLiteParams(%q) = %q, want %q", source, got, want)
+		}
+	}
+
+	if _, err := WithSQLiteParams("zapmeow.db?_busy_timeout=%zz", params); err == nil {
+		t.Error("an invalid query was accepted")
+	}
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a versioned schema change. Up and Down run in a transaction,
+// and Down must undo exactly what Up did.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrateUp applies every pending migration in version order.
+func (d *database) MigrateUp(migrations []Migration) error {
+	applied, err := d.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range sortMigrations(migrations) {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := d.client.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// MigrateDown rolls back the last steps applied migrations.
+func (d *database) MigrateDown(migrations []Migration, steps int) error {
+	applied, err := d.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	sorted := sortMigrations(migrations)
+	for i := len(sorted) - 1; i >= 0 && steps > 0; i-- {
+		migration := sorted[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := d.client.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		steps--
+	}
+	return nil
+}
+
+func (d *database) MigrationStatus(migrations []Migration) ([]MigrationStatus, error) {
+	applied, err := d.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var status []MigrationStatus
+	for _, migration := range sortMigrations(migrations) {
+		item := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if record, ok := applied[migration.Version]; ok {
+			item.Applied = true
+			item.AppliedAt = &record.AppliedAt
+		}
+		status = append(status, item)
+	}
+	return status, nil
+}
+
+func (d *database) appliedMigrations() (map[uint]schemaMigration, error) {
+	if err := d.client.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var records []schemaMigration
+	if err := d.client.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[uint]schemaMigration{}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func sortMigrations(migrations []Migration) []Migration {
+	sorted := append([]Migration{}, migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	return sorted
+}
@@ -4,9 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
+// requestTimeout bounds every request, so shutdown never waits on a
+// webhook endpoint that hangs.
+const requestTimeout = 10 * time.Second
+
 func Request(url string, data map[string]interface{}) error {
 	body, err := json.Marshal(data)
 	if err != nil {
@@ -19,7 +26,7 @@ func Request(url string, data map[string]interface{}) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := &http.Client{Timeout: requestTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
 		return errors.New("Request returned an unexpected status code")
@@ -28,3 +35,26 @@ func Request(url string, data map[string]interface{}) error {
 	defer resp.Body.Close()
 	return nil
 }
+
+// Download fetches a file, failing when it is larger than maxSize bytes.
+func Download(url string, maxSize int64) ([]byte, error) {
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download returned status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errors.New("download is larger than the allowed size")
+	}
+	return data, nil
+}
@@ -0,0 +1,62 @@
+package pubsub
+
+import "sync"
+
+type Message struct {
+	Event string
+	Data  interface{}
+}
+
+type PubSub interface {
+	Subscribe(topic string) (<-chan Message, func())
+	Publish(topic string, message Message)
+}
+
+type pubSub struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan Message]struct{}
+}
+
+func NewPubSub() *pubSub {
+	return &pubSub{
+		subscribers: map[string]map[chan Message]struct{}{},
+	}
+}
+
+// Subscribe returns a channel with the messages published to the topic and
+// a function that must be called to stop receiving them.
+func (p *pubSub) Subscribe(topic string) (<-chan Message, func()) {
+	ch := make(chan Message, 16)
+
+	p.mutex.Lock()
+	if p.subscribers[topic] == nil {
+		p.subscribers[topic] = map[chan Message]struct{}{}
+	}
+	p.subscribers[topic][ch] = struct{}{}
+	p.mutex.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			p.mutex.Lock()
+			delete(p.subscribers[topic], ch)
+			if len(p.subscribers[topic]) == 0 {
+				delete(p.subscribers, topic)
+			}
+			p.mutex.Unlock()
+		})
+	}
+}
+
+// Publish never blocks; slow subscribers miss messages instead.
+func (p *pubSub) Publish(topic string, message Message) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for ch := range p.subscribers[topic] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+}
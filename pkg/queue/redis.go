@@ -9,6 +9,7 @@ import (
 type Queue interface {
 	Enqueue(queueName string, data []byte) error
 	Dequeue(queueName string) ([]byte, error)
+	Close() error
 }
 
 type queue struct {
@@ -42,3 +43,7 @@ func (q *queue) Dequeue(queueName string) ([]byte, error) {
 	}
 	return result, nil
 }
+
+func (q *queue) Close() error {
+	return q.client.Close()
+}
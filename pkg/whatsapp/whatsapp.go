@@ -4,13 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 	"zapmeow/config"
+	"zapmeow/pkg/database"
 	"zapmeow/pkg/logger"
 
+	_ "github.com/lib/pq"
 	"github.com/vincent-petithory/dataurl"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/appstate"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waMmsRetry"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	"go.mau.fi/whatsmeow/types"
@@ -21,25 +31,50 @@ import (
 
 type Client = whatsmeow.Client
 
+var ErrInvalidPrivacySetting = errors.New("invalid privacy setting")
+
+// privacyValues lists what each privacy setting accepts.
+var privacyValues = map[types.PrivacySettingType][]types.PrivacySetting{
+	types.PrivacySettingTypeLastSeen:     {types.PrivacySettingAll, types.PrivacySettingContacts, types.PrivacySettingContactBlacklist, types.PrivacySettingNone},
+	types.PrivacySettingTypeOnline:       {types.PrivacySettingAll, types.PrivacySettingMatchLastSeen},
+	types.PrivacySettingTypeProfile:      {types.PrivacySettingAll, types.PrivacySettingContacts, types.PrivacySettingContactBlacklist, types.PrivacySettingNone},
+	types.PrivacySettingTypeStatus:       {types.PrivacySettingAll, types.PrivacySettingContacts, types.PrivacySettingContactBlacklist, types.PrivacySettingNone},
+	types.PrivacySettingTypeReadReceipts: {types.PrivacySettingAll, types.PrivacySettingNone},
+	types.PrivacySettingTypeGroupAdd:     {types.PrivacySettingAll, types.PrivacySettingContacts, types.PrivacySettingContactBlacklist, types.PrivacySettingNone},
+	types.PrivacySettingTypeCallAdd:      {types.PrivacySettingAll, types.PrivacySettingKnown},
+}
+
 type JID = types.JID
 
 type Instance struct {
 	ID              string
 	Client          *Client
 	QrCodeRateLimit uint16
+	// Pairing is set when logging in with a pairing code instead of the
+	// qrcode, and read by the login goroutine
+	Pairing     atomic.Bool
+	qrCodeReady chan struct{}
+	qrCodeOnce  sync.Once
 }
 
 type Message struct {
 	InstanceID string
 	Body       string
+	Caption    string
 	SenderJID  string
 	ChatJID    string
+	Sender     JID
+	Chat       JID
 	MessageID  string
 	FromMe     bool
 	Timestamp  time.Time
 	MediaType  *MediaType
-	Media      *[]byte
 	Mimetype   *string
+	Thumbnail  *[]byte
+	Raw        []byte
+	// Edited and Revoked replace the content of the message MessageID
+	Edited  bool
+	Revoked bool
 }
 
 type MediaType int
@@ -49,7 +84,7 @@ const (
 	Image
 	Document
 	Sticker
-	// Video
+	Video
 )
 
 func (m MediaType) String() string {
@@ -60,14 +95,23 @@ func (m MediaType) String() string {
 		return "document"
 	case Sticker:
 		return "sticker"
-	// case Video:
-	// 	return "video"
+	case Video:
+		return "video"
 	case Image:
 		return "image"
 	}
 	return "unknown"
 }
 
+// Contact is what the session store knows about a user.
+type Contact struct {
+	JID          JID
+	FirstName    string
+	FullName     string
+	PushName     string
+	BusinessName string
+}
+
 type ContactInfo struct {
 	Phone   string `json:"phone"`
 	Name    string `json:"name"`
@@ -75,16 +119,101 @@ type ContactInfo struct {
 	Picture string `json:"picture"`
 }
 
+// UserInfo is what WhatsApp shares about an account with anyone. PictureID
+// changes whenever the profile picture does.
+type UserInfo struct {
+	JID          JID
+	PushName     string
+	BusinessName string
+	Status       string
+	PictureID    string
+}
+
+type ProfilePicture struct {
+	ID  string
+	URL string
+}
+
+// PrivacySettings holds who can see or do what with the account. Empty
+// fields are left unchanged by SetPrivacySettings. Status is who sees the
+// about text; who receives status updates is the StatusAudience.
+type PrivacySettings struct {
+	LastSeen     string `json:"last_seen"`
+	Online       string `json:"online"`
+	Profile      string `json:"profile"`
+	Status       string `json:"status"`
+	ReadReceipts string `json:"read_receipts"`
+	GroupAdd     string `json:"group_add"`
+	CallAdd      string `json:"call_add"`
+}
+
+// TextStatus is a text posted to status over a solid background. Colors
+// are ARGB.
+type TextStatus struct {
+	Text            string
+	BackgroundColor uint32
+	TextColor       uint32
+	Font            int32
+}
+
+// StatusAudience is who receives the status updates of the account, as set
+// in its status privacy. whatsmeow can read it but not change it, and sends
+// every update to it.
+type StatusAudience struct {
+	Type string `json:"type"`
+	// List holds the included or excluded contacts
+	List []JID `json:"list"`
+}
+
+// Newsletter is a WhatsApp channel. Role and Muted describe the instance
+// and are empty for channels it doesn't follow.
+type Newsletter struct {
+	JID             JID
+	Name            string
+	Description     string
+	InviteCode      string
+	SubscriberCount int
+	Verified        bool
+	State           string
+	Role            string
+	Muted           bool
+	PictureURL      string
+	CreatedAt       time.Time
+}
+
+// NewsletterMessage is a post in a channel. ServerID orders the posts and
+// pages through them.
+type NewsletterMessage struct {
+	ServerID  int
+	MessageID string
+	Timestamp time.Time
+	Views     int
+	Reactions map[string]int
+	Body      string
+	Caption   string
+	MediaType *MediaType
+}
+
 type MessageResponse struct {
 	ID        string
 	Sender    JID
 	Timestamp time.Time
+	// ServerID is only set for messages sent to newsletters
+	ServerID int
 }
 
 type DownloadResponse struct {
-	Data     []byte
-	Type     MediaType
-	Mimetype string
+	Data      []byte
+	Type      MediaType
+	Mimetype  string
+	Thumbnail []byte
+}
+
+type MediaRetryInfo struct {
+	MessageID string
+	Chat      JID
+	Sender    JID
+	FromMe    bool
 }
 
 type UploadResponse struct {
@@ -97,6 +226,15 @@ type UploadResponse struct {
 	FileLength    uint64
 }
 
+// LastMessage is the newest message of a chat, which chat changes refer to
+// so every device applies them to the same messages.
+type LastMessage struct {
+	ID        string
+	FromMe    bool
+	Sender    JID
+	Timestamp time.Time
+}
+
 type IsOnWhatsAppResponse struct {
 	Query        string `json:"query"`
 	Phone        string `json:"phone"`
@@ -109,23 +247,62 @@ type WhatsApp interface {
 	IsLoggedIn(instance *Instance) bool
 	IsConnected(instance *Instance) bool
 	Disconnect(instance *Instance)
+	Reconnect(instance *Instance) error
 	Logout(instance *Instance) error
+	DeleteDevice(instance *Instance) error
 	EventHandler(instance *Instance, handler func(evt interface{}))
 	InitInstance(instance *Instance, qrcodeHandler func(evt string, qrcode string, err error)) error
+	PairPhone(instance *Instance, phone string) (string, error)
 	SendTextMessage(instance *Instance, jid JID, text string) (MessageResponse, error)
 	SendAudioMessage(instance *Instance, jid JID, audioURL *dataurl.DataURL, mimitype string) (MessageResponse, error)
 	SendImageMessage(instance *Instance, jid JID, imageURL *dataurl.DataURL, mimitype string) (MessageResponse, error)
 	SendDocumentMessage(instance *Instance, jid JID, documentURL *dataurl.DataURL, mimitype string, filename string) (MessageResponse, error)
 	GetContactInfo(instance *Instance, jid JID) (*ContactInfo, error)
+	GetContacts(instance *Instance) ([]Contact, error)
+	GetUsersInfo(instance *Instance, jids []JID) (map[JID]UserInfo, error)
+	GetProfilePicture(instance *Instance, jid JID) (*ProfilePicture, error)
 	ParseEventMessage(instance *Instance, message *events.Message) (Message, error)
+	DownloadMedia(instance *Instance, raw []byte) (*DownloadResponse, error)
+	RequestMediaRetry(instance *Instance, raw []byte, info MediaRetryInfo) error
+	ApplyMediaRetry(raw []byte, evt *events.MediaRetry) ([]byte, error)
 	IsOnWhatsApp(instance *Instance, phones []string) ([]IsOnWhatsAppResponse, error)
+	MarkRead(instance *Instance, messageID string, chat JID, sender JID) error
+	Close() error
+	RejectCall(instance *Instance, from JID, callID string) error
+	ArchiveChat(instance *Instance, chat JID, archive bool, last *LastMessage) error
+	PinChat(instance *Instance, chat JID, pin bool) error
+	MuteChat(instance *Instance, chat JID, mute bool, duration time.Duration) error
+	MarkChatRead(instance *Instance, chat JID, read bool, last *LastMessage) error
+	ClearChat(instance *Instance, chat JID, last *LastMessage) error
+	DeleteChat(instance *Instance, chat JID, last *LastMessage) error
+	GetBlocklist(instance *Instance) ([]JID, error)
+	SendTextStatus(instance *Instance, status TextStatus) (MessageResponse, error)
+	SendImageStatus(instance *Instance, imageURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error)
+	SendVideoStatus(instance *Instance, videoURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error)
+	GetStatusAudience(instance *Instance) (StatusAudience, error)
+	SetPushName(instance *Instance, name string) error
+	SetAbout(instance *Instance, about string) error
+	SetProfilePicture(instance *Instance, picture []byte) (string, error)
+	GetPrivacySettings(instance *Instance) (PrivacySettings, error)
+	SetPrivacySettings(instance *Instance, settings PrivacySettings) (PrivacySettings, error)
+	UpdateBlocklist(instance *Instance, jid JID, block bool) ([]JID, error)
+	CreateNewsletter(instance *Instance, name string, description string, picture []byte) (*Newsletter, error)
+	GetNewsletter(instance *Instance, jid JID) (*Newsletter, error)
+	GetNewsletterByInvite(instance *Instance, code string) (*Newsletter, error)
+	GetSubscribedNewsletters(instance *Instance) ([]Newsletter, error)
+	FollowNewsletter(instance *Instance, jid JID, follow bool) error
+	GetNewsletterMessages(instance *Instance, jid JID, count int, before int) ([]NewsletterMessage, error)
+	SendNewsletterText(instance *Instance, jid JID, text string) (MessageResponse, error)
+	SendNewsletterImage(instance *Instance, jid JID, imageURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error)
+	ParseNewsletterMessage(message *events.Message) NewsletterMessage
+	MakeNewsletter(metadata *types.NewsletterMetadata) Newsletter
 }
 
 type whatsApp struct {
 	container *sqlstore.Container
 }
 
-func NewWhatsApp(databasePath string) *whatsApp {
+func NewWhatsApp(dsn string) *whatsApp {
 	cfg := config.Load()
 
 	var level = "DEBUG"
@@ -134,20 +311,37 @@ func NewWhatsApp(databasePath string) *whatsApp {
 	}
 	dbLog := waLog.Stdout("Database", level, true)
 
-	container, err := sqlstore.New(context.Background(), "sqlite3", "file:"+databasePath+"?_foreign_keys=on", dbLog)
+	driver, source, err := database.ParseDSN(dsn)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	// sqlstore runs its own migrations when opening the store
+	var container *sqlstore.Container
+	switch driver {
+	case database.Postgres:
+		container, err = sqlstore.New(context.Background(), "postgres", source, dbLog)
+	default:
+		source, err = database.WithSQLiteParams(source, url.Values{"_foreign_keys": {"on"}})
+		if err != nil {
+			logger.Fatal(err)
+		}
+		container, err = sqlstore.New(context.Background(), "sqlite3", "file:"+source, dbLog)
+	}
 	if err != nil {
 		logger.Fatal(err)
 	}
 	return &whatsApp{container: container}
 }
 
+// Close releases the session store, once every instance is disconnected.
+func (w *whatsApp) Close() error {
+	return w.container.Close()
+}
+
 func (w *whatsApp) CreateInstance(id string) *Instance {
 	client := w.createClient(w.container.NewDevice())
-	return &Instance{
-		ID:              id,
-		Client:          client,
-		QrCodeRateLimit: 10,
-	}
+	return newInstance(id, client)
 }
 
 func (w *whatsApp) CreateInstanceFromDevice(id string, jid JID) *Instance {
@@ -160,15 +354,20 @@ func (w *whatsApp) CreateInstanceFromDevice(id string, jid JID) *Instance {
 	})
 	if device != nil {
 		client := w.createClient(device)
-		return &Instance{
-			ID:              id,
-			Client:          client,
-			QrCodeRateLimit: 10,
-		}
+		return newInstance(id, client)
 	}
 	return w.CreateInstance(id)
 }
 
+func newInstance(id string, client *Client) *Instance {
+	return &Instance{
+		ID:              id,
+		Client:          client,
+		QrCodeRateLimit: 10,
+		qrCodeReady:     make(chan struct{}),
+	}
+}
+
 func (w *whatsApp) IsLoggedIn(instance *Instance) bool {
 	return instance.Client.IsLoggedIn()
 }
@@ -181,14 +380,34 @@ func (w *whatsApp) Disconnect(instance *Instance) {
 	instance.Client.Disconnect()
 }
 
-func (w *whatsApp) Connect(instance *Instance) {
+// Reconnect drops the current websocket, if any, and waits for the client
+// to log in again with the stored session.
+func (w *whatsApp) Reconnect(instance *Instance) error {
 	instance.Client.Disconnect()
+	err := instance.Client.Connect()
+	if err != nil {
+		return err
+	}
+
+	if !instance.Client.WaitForConnection(30 * time.Second) {
+		return errors.New("websocket didn't log in within 30 seconds")
+	}
+	return nil
 }
 
 func (w *whatsApp) Logout(instance *Instance) error {
 	return instance.Client.Logout(context.Background())
 }
 
+// DeleteDevice wipes the device keys and sessions from the store, so the
+// instance has to be paired again.
+func (w *whatsApp) DeleteDevice(instance *Instance) error {
+	if instance.Client.Store.ID == nil {
+		return nil
+	}
+	return instance.Client.Store.Delete(context.Background())
+}
+
 func (w *whatsApp) EventHandler(instance *Instance, handler func(evt interface{})) {
 	instance.Client.AddEventHandler(handler)
 }
@@ -210,6 +429,24 @@ func (w *whatsApp) InitInstance(instance *Instance, qrcodeHandler func(evt strin
 	return nil
 }
 
+// PairPhone returns the 8-character code to link the instance from the
+// phone. The login websocket must be up, so it waits for the first qrcode.
+func (w *whatsApp) PairPhone(instance *Instance, phone string) (string, error) {
+	select {
+	case <-instance.qrCodeReady:
+	case <-time.After(15 * time.Second):
+		return "", errors.New("login websocket didn't connect within 15 seconds")
+	}
+
+	return instance.Client.PairPhone(
+		context.Background(),
+		phone,
+		true,
+		whatsmeow.PairClientChrome,
+		"Chrome (Linux)",
+	)
+}
+
 func (w *whatsApp) SendTextMessage(instance *Instance, jid JID, text string) (MessageResponse, error) {
 	message := &waProto.Message{
 		ExtendedTextMessage: &waProto.ExtendedTextMessage{
@@ -258,6 +495,80 @@ func (w *whatsApp) SendImageMessage(instance *Instance, jid JID, imageURL *datau
 	return w.sendMessage(instance, jid, message)
 }
 
+// SendTextStatus posts to the audience chosen in the status privacy of the
+// account, as do the other status senders; whatsmeow can not address a
+// single update to anyone else.
+func (w *whatsApp) SendTextStatus(instance *Instance, status TextStatus) (MessageResponse, error) {
+	message := &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:           proto.String(status.Text),
+			BackgroundArgb: proto.Uint32(status.BackgroundColor),
+			TextArgb:       proto.Uint32(status.TextColor),
+			Font:           waProto.ExtendedTextMessage_FontType(status.Font).Enum(),
+		},
+	}
+	return w.sendMessage(instance, types.StatusBroadcastJID, message)
+}
+
+func (w *whatsApp) SendImageStatus(
+	instance *Instance, imageURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error) {
+	uploaded, err := w.uploadMedia(instance, imageURL, Image)
+	if err != nil {
+		return MessageResponse{}, err
+	}
+
+	message := &waProto.Message{
+		ImageMessage: &waProto.ImageMessage{
+			Caption:       proto.String(caption),
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(mimitype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uint64(len(imageURL.Data))),
+		},
+	}
+	return w.sendMessage(instance, types.StatusBroadcastJID, message)
+}
+
+func (w *whatsApp) SendVideoStatus(
+	instance *Instance, videoURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error) {
+	uploaded, err := w.uploadMedia(instance, videoURL, Video)
+	if err != nil {
+		return MessageResponse{}, err
+	}
+
+	message := &waProto.Message{
+		VideoMessage: &waProto.VideoMessage{
+			Caption:       proto.String(caption),
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(mimitype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uint64(len(videoURL.Data))),
+		},
+	}
+	return w.sendMessage(instance, types.StatusBroadcastJID, message)
+}
+
+func (w *whatsApp) GetStatusAudience(instance *Instance) (StatusAudience, error) {
+	privacy, err := instance.Client.GetStatusPrivacy()
+	if err != nil {
+		return StatusAudience{}, err
+	}
+
+	// the first option is the one in use
+	audience := StatusAudience{List: []JID{}}
+	if len(privacy) > 0 {
+		audience.Type = string(privacy[0].Type)
+		audience.List = append(audience.List, privacy[0].List...)
+	}
+	return audience, nil
+}
+
 func (w *whatsApp) SendDocumentMessage(
 	instance *Instance, jid JID, documentURL *dataurl.DataURL, mimitype string, filename string) (MessageResponse, error) {
 	uploaded, err := w.uploadMedia(instance, documentURL, Document)
@@ -297,6 +608,119 @@ func (w *whatsApp) IsOnWhatsApp(instance *Instance, phones []string) ([]IsOnWhat
 	return data, nil
 }
 
+func (w *whatsApp) MarkRead(instance *Instance, messageID string, chat JID, sender JID) error {
+	return instance.Client.MarkRead(
+		[]types.MessageID{messageID},
+		time.Now(),
+		chat,
+		sender,
+	)
+}
+
+func (w *whatsApp) RejectCall(instance *Instance, from JID, callID string) error {
+	return instance.Client.RejectCall(from, callID)
+}
+
+func (w *whatsApp) ArchiveChat(instance *Instance, chat JID, archive bool, last *LastMessage) error {
+	var timestamp time.Time
+	if last != nil {
+		timestamp = last.Timestamp
+	}
+	patch := appstate.BuildArchive(chat, archive, timestamp, last.key(chat))
+	return instance.Client.SendAppState(context.Background(), patch)
+}
+
+func (w *whatsApp) PinChat(instance *Instance, chat JID, pin bool) error {
+	return instance.Client.SendAppState(context.Background(), appstate.BuildPin(chat, pin))
+}
+
+// MuteChat mutes the chat for the duration, or forever when it is zero.
+func (w *whatsApp) MuteChat(instance *Instance, chat JID, mute bool, duration time.Duration) error {
+	return instance.Client.SendAppState(context.Background(), appstate.BuildMute(chat, mute, duration))
+}
+
+// whatsmeow has no builders for the patches below, so they follow the
+// indexes and versions used by WhatsApp Web.
+
+func (w *whatsApp) MarkChatRead(instance *Instance, chat JID, read bool, last *LastMessage) error {
+	return instance.Client.SendAppState(context.Background(), appstate.PatchInfo{
+		Type: appstate.WAPatchRegularLow,
+		Mutations: []appstate.MutationInfo{{
+			Index:   []string{appstate.IndexMarkChatAsRead, chat.String()},
+			Version: 3,
+			Value: &waSyncAction.SyncActionValue{
+				MarkChatAsReadAction: &waSyncAction.MarkChatAsReadAction{
+					Read:         proto.Bool(read),
+					MessageRange: last.messageRange(chat),
+				},
+			},
+		}},
+	})
+}
+
+func (w *whatsApp) ClearChat(instance *Instance, chat JID, last *LastMessage) error {
+	return instance.Client.SendAppState(context.Background(), appstate.PatchInfo{
+		Type: appstate.WAPatchRegularHigh,
+		Mutations: []appstate.MutationInfo{{
+			// also clears starred messages, and keeps the media on the phone
+			Index:   []string{appstate.IndexClearChat, chat.String(), "1", "0"},
+			Version: 6,
+			Value: &waSyncAction.SyncActionValue{
+				ClearChatAction: &waSyncAction.ClearChatAction{
+					MessageRange: last.messageRange(chat),
+				},
+			},
+		}},
+	})
+}
+
+func (w *whatsApp) DeleteChat(instance *Instance, chat JID, last *LastMessage) error {
+	return instance.Client.SendAppState(context.Background(), appstate.PatchInfo{
+		Type: appstate.WAPatchRegularHigh,
+		Mutations: []appstate.MutationInfo{{
+			Index:   []string{appstate.IndexDeleteChat, chat.String(), "1"},
+			Version: 6,
+			Value: &waSyncAction.SyncActionValue{
+				DeleteChatAction: &waSyncAction.DeleteChatAction{
+					MessageRange: last.messageRange(chat),
+				},
+			},
+		}},
+	})
+}
+
+func (m *LastMessage) key(chat JID) *waCommon.MessageKey {
+	if m == nil {
+		return nil
+	}
+
+	key := &waCommon.MessageKey{
+		RemoteJID: proto.String(chat.String()),
+		FromMe:    proto.Bool(m.FromMe),
+		ID:        proto.String(m.ID),
+	}
+	if chat.Server == types.GroupServer && !m.FromMe {
+		key.Participant = proto.String(m.Sender.String())
+	}
+	return key
+}
+
+func (m *LastMessage) messageRange(chat JID) *waSyncAction.SyncActionMessageRange {
+	if m == nil {
+		return &waSyncAction.SyncActionMessageRange{
+			LastMessageTimestamp: proto.Int64(time.Now().Unix()),
+		}
+	}
+
+	return &waSyncAction.SyncActionMessageRange{
+		LastMessageTimestamp: proto.Int64(m.Timestamp.Unix()),
+		Messages: []*waSyncAction.SyncActionMessage{{
+			Key:       m.key(chat),
+			Timestamp: proto.Int64(m.Timestamp.Unix()),
+		}},
+	}
+}
+
 func (w *whatsApp) sendMessage(instance *Instance, jid JID, message *waProto.Message) (MessageResponse, error) {
 	resp, err := instance.Client.SendMessage(context.Background(), jid, message)
 	if err != nil {
@@ -307,6 +731,7 @@ func (w *whatsApp) sendMessage(instance *Instance, jid JID, message *waProto.Mes
 		ID:        resp.ID,
 		Sender:    *instance.Client.Store.ID,
 		Timestamp: resp.Timestamp,
+		ServerID:  resp.ServerID,
 	}, nil
 }
 
@@ -339,37 +764,485 @@ func (w *whatsApp) GetContactInfo(instance *Instance, jid JID) (*ContactInfo, er
 	}, nil
 }
 
-func (w *whatsApp) ParseEventMessage(instance *Instance, message *events.Message) (Message, error) {
-	media, err := w.downloadMedia(
-		instance,
-		message.Message,
-	)
+// GetUsersInfo looks up every JID in a single query. JIDs that are not on
+// WhatsApp are left out of the result.
+func (w *whatsApp) GetUsersInfo(instance *Instance, jids []JID) (map[JID]UserInfo, error) {
+	users, err := instance.Client.GetUserInfo(jids)
+	if err != nil {
+		return nil, err
+	}
 
-	if err != nil && media == nil {
-		return Message{}, err
+	data := make(map[JID]UserInfo, len(users))
+	for jid, user := range users {
+		info := UserInfo{
+			JID:       jid,
+			Status:    user.Status,
+			PictureID: user.PictureID,
+		}
+		if user.VerifiedName != nil {
+			info.BusinessName = user.VerifiedName.Details.GetVerifiedName()
+		}
+
+		contact, err := instance.Client.Store.Contacts.GetContact(context.Background(), jid)
+		if err != nil {
+			return nil, err
+		}
+		info.PushName = contact.PushName
+
+		data[jid] = info
+	}
+	return data, nil
+}
+
+// GetProfilePicture returns nil when the user has no picture or hides it
+// from this account.
+func (w *whatsApp) GetProfilePicture(instance *Instance, jid JID) (*ProfilePicture, error) {
+	picture, err := instance.Client.GetProfilePictureInfo(jid, &whatsmeow.GetProfilePictureParams{})
+	if errors.Is(err, whatsmeow.ErrProfilePictureNotSet) || errors.Is(err, whatsmeow.ErrProfilePictureUnauthorized) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if picture == nil {
+		return nil, nil
+	}
+
+	return &ProfilePicture{
+		ID:  picture.ID,
+		URL: picture.URL,
+	}, nil
+}
+
+func (w *whatsApp) GetContacts(instance *Instance) ([]Contact, error) {
+	contacts, err := instance.Client.Store.Contacts.GetAllContacts(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
-	text := w.getTextMessage(message.Message)
+	var data []Contact
+	for jid, info := range contacts {
+		data = append(data, Contact{
+			JID:          jid,
+			FirstName:    info.FirstName,
+			FullName:     info.FullName,
+			PushName:     info.PushName,
+			BusinessName: info.BusinessName,
+		})
+	}
+	return data, nil
+}
+
+func (w *whatsApp) GetBlocklist(instance *Instance) ([]JID, error) {
+	blocklist, err := instance.Client.GetBlocklist()
+	if err != nil {
+		return nil, err
+	}
+	return blocklist.JIDs, nil
+}
+
+// UpdateBlocklist blocks or unblocks the JID and returns the new blocklist.
+func (w *whatsApp) UpdateBlocklist(instance *Instance, jid JID, block bool) ([]JID, error) {
+	action := events.BlocklistChangeActionUnblock
+	if block {
+		action = events.BlocklistChangeActionBlock
+	}
+
+	blocklist, err := instance.Client.UpdateBlocklist(jid, action)
+	if err != nil {
+		return nil, err
+	}
+	return blocklist.JIDs, nil
+}
+
+func (w *whatsApp) SetPushName(instance *Instance, name string) error {
+	return instance.Client.SendAppState(context.Background(), appstate.BuildSettingPushName(name))
+}
+
+func (w *whatsApp) SetAbout(instance *Instance, about string) error {
+	return instance.Client.SetStatusMessage(about)
+}
+
+// SetProfilePicture takes a JPEG, or nil to remove the picture, and returns
+// the ID of the new picture.
+func (w *whatsApp) SetProfilePicture(instance *Instance, picture []byte) (string, error) {
+	return instance.Client.SetGroupPhoto(types.EmptyJID, picture)
+}
+
+func (w *whatsApp) GetPrivacySettings(instance *Instance) (PrivacySettings, error) {
+	settings, err := instance.Client.TryFetchPrivacySettings(context.Background(), true)
+	if err != nil {
+		return PrivacySettings{}, err
+	}
+	return makePrivacySettings(*settings), nil
+}
+
+// SetPrivacySettings validates every given setting before changing any.
+func (w *whatsApp) SetPrivacySettings(instance *Instance, settings PrivacySettings) (PrivacySettings, error) {
+	changes := map[types.PrivacySettingType]string{
+		types.PrivacySettingTypeLastSeen:     settings.LastSeen,
+		types.PrivacySettingTypeOnline:       settings.Online,
+		types.PrivacySettingTypeProfile:      settings.Profile,
+		types.PrivacySettingTypeStatus:       settings.Status,
+		types.PrivacySettingTypeReadReceipts: settings.ReadReceipts,
+		types.PrivacySettingTypeGroupAdd:     settings.GroupAdd,
+		types.PrivacySettingTypeCallAdd:      settings.CallAdd,
+	}
+	for name, value := range changes {
+		if value != "" && !slices.Contains(privacyValues[name], types.PrivacySetting(value)) {
+			return PrivacySettings{}, fmt.Errorf("%w: %s can not be %q", ErrInvalidPrivacySetting, name, value)
+		}
+	}
+
+	current := PrivacySettings{}
+	for name, value := range changes {
+		if value == "" {
+			continue
+		}
+		updated, err := instance.Client.SetPrivacySetting(context.Background(), name, types.PrivacySetting(value))
+		if err != nil {
+			return PrivacySettings{}, err
+		}
+		current = makePrivacySettings(updated)
+	}
+
+	if current == (PrivacySettings{}) {
+		return w.GetPrivacySettings(instance)
+	}
+	return current, nil
+}
+
+func makePrivacySettings(settings types.PrivacySettings) PrivacySettings {
+	return PrivacySettings{
+		LastSeen:     string(settings.LastSeen),
+		Online:       string(settings.Online),
+		Profile:      string(settings.Profile),
+		Status:       string(settings.Status),
+		ReadReceipts: string(settings.ReadReceipts),
+		GroupAdd:     string(settings.GroupAdd),
+		CallAdd:      string(settings.CallAdd),
+	}
+}
+
+// newsletterTOSNotice is the terms of service WhatsApp asks to accept before
+// creating the first channel.
+const (
+	newsletterTOSNotice = "20601218"
+	newsletterTOSStage  = "5"
+)
+
+// CreateNewsletter takes an optional JPEG picture.
+func (w *whatsApp) CreateNewsletter(instance *Instance, name string, description string, picture []byte) (*Newsletter, error) {
+	if err := instance.Client.AcceptTOSNotice(newsletterTOSNotice, newsletterTOSStage); err != nil {
+		return nil, err
+	}
+
+	metadata, err := instance.Client.CreateNewsletter(whatsmeow.CreateNewsletterParams{
+		Name:        name,
+		Description: description,
+		Picture:     picture,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newsletter := w.MakeNewsletter(metadata)
+	return &newsletter, nil
+}
+
+// GetNewsletter returns nil when the channel does not exist.
+func (w *whatsApp) GetNewsletter(instance *Instance, jid JID) (*Newsletter, error) {
+	metadata, err := instance.Client.GetNewsletterInfo(jid)
+	if err != nil || metadata == nil {
+		return nil, err
+	}
+
+	newsletter := w.MakeNewsletter(metadata)
+	return &newsletter, nil
+}
+
+// GetNewsletterByInvite takes the code of a channel link, as in
+// https://whatsapp.com/channel/<code>, and returns nil when it is unknown.
+func (w *whatsApp) GetNewsletterByInvite(instance *Instance, code string) (*Newsletter, error) {
+	metadata, err := instance.Client.GetNewsletterInfoWithInvite(code)
+	if err != nil || metadata == nil {
+		return nil, err
+	}
+
+	newsletter := w.MakeNewsletter(metadata)
+	return &newsletter, nil
+}
+
+func (w *whatsApp) GetSubscribedNewsletters(instance *Instance) ([]Newsletter, error) {
+	subscribed, err := instance.Client.GetSubscribedNewsletters()
+	if err != nil {
+		return nil, err
+	}
+
+	newsletters := make([]Newsletter, 0, len(subscribed))
+	for _, metadata := range subscribed {
+		newsletters = append(newsletters, w.MakeNewsletter(metadata))
+	}
+	return newsletters, nil
+}
+
+func (w *whatsApp) FollowNewsletter(instance *Instance, jid JID, follow bool) error {
+	if follow {
+		return instance.Client.FollowNewsletter(jid)
+	}
+	return instance.Client.UnfollowNewsletter(jid)
+}
+
+// GetNewsletterMessages returns up to count posts older than the before
+// server ID, or the newest ones when before is zero.
+func (w *whatsApp) GetNewsletterMessages(instance *Instance, jid JID, count int, before int) ([]NewsletterMessage, error) {
+	posts, err := instance.Client.GetNewsletterMessages(jid, &whatsmeow.GetNewsletterMessagesParams{
+		Count:  count,
+		Before: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]NewsletterMessage, 0, len(posts))
+	for _, post := range posts {
+		message := NewsletterMessage{
+			ServerID:  post.MessageServerID,
+			MessageID: post.MessageID,
+			Timestamp: post.Timestamp,
+			Views:     post.ViewsCount,
+			Reactions: post.ReactionCounts,
+		}
+		w.setNewsletterContent(&message, post.Message)
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (w *whatsApp) SendNewsletterText(instance *Instance, jid JID, text string) (MessageResponse, error) {
+	message := &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: &text,
+		},
+	}
+	return w.sendMessage(instance, jid, message)
+}
+
+// SendNewsletterImage uploads the image unencrypted, as channels require,
+// and refers to the upload by its handle.
+func (w *whatsApp) SendNewsletterImage(
+	instance *Instance, jid JID, imageURL *dataurl.DataURL, mimitype string, caption string) (MessageResponse, error) {
+	uploaded, err := instance.Client.UploadNewsletter(context.Background(), imageURL.Data, whatsmeow.MediaImage)
+	if err != nil {
+		return MessageResponse{}, err
+	}
+
+	message := &waProto.Message{
+		ImageMessage: &waProto.ImageMessage{
+			Caption:    proto.String(caption),
+			URL:        proto.String(uploaded.URL),
+			DirectPath: proto.String(uploaded.DirectPath),
+			Mimetype:   proto.String(mimitype),
+			FileSHA256: uploaded.FileSHA256,
+			FileLength: proto.Uint64(uploaded.FileLength),
+		},
+	}
+
+	resp, err := instance.Client.SendMessage(context.Background(), jid, message, whatsmeow.SendRequestExtra{
+		MediaHandle: uploaded.Handle,
+	})
+	if err != nil {
+		return MessageResponse{}, err
+	}
+
+	return MessageResponse{
+		ID:        resp.ID,
+		Sender:    *instance.Client.Store.ID,
+		Timestamp: resp.Timestamp,
+		ServerID:  resp.ServerID,
+	}, nil
+}
+
+// ParseNewsletterMessage reads a post received from a followed channel.
+func (w *whatsApp) ParseNewsletterMessage(message *events.Message) NewsletterMessage {
+	parsed := NewsletterMessage{
+		MessageID: message.Info.ID,
+		ServerID:  message.Info.ServerID,
+		Timestamp: message.Info.Timestamp,
+	}
+	w.setNewsletterContent(&parsed, message.Message)
+	return parsed
+}
+
+func (w *whatsApp) setNewsletterContent(message *NewsletterMessage, content *waProto.Message) {
+	if content == nil {
+		return
+	}
+
+	message.Body = w.getTextMessage(content)
+	message.Caption = w.getCaption(content)
+	if _, media := w.getMedia(content); media != nil {
+		message.MediaType = &media.Type
+	}
+}
+
+func (w *whatsApp) MakeNewsletter(metadata *types.NewsletterMetadata) Newsletter {
+	thread := metadata.ThreadMeta
+	newsletter := Newsletter{
+		JID:             metadata.ID,
+		Name:            thread.Name.Text,
+		Description:     thread.Description.Text,
+		InviteCode:      thread.InviteCode,
+		SubscriberCount: thread.SubscriberCount,
+		Verified:        thread.VerificationState == types.NewsletterVerificationStateVerified,
+		State:           string(metadata.State.Type),
+		CreatedAt:       thread.CreationTime.Time,
+	}
+	if thread.Picture != nil {
+		newsletter.PictureURL = thread.Picture.URL
+	} else {
+		newsletter.PictureURL = thread.Preview.URL
+	}
+	if metadata.ViewerMeta != nil {
+		newsletter.Role = string(metadata.ViewerMeta.Role)
+		newsletter.Muted = metadata.ViewerMeta.Mute == types.NewsletterMuteOn
+	}
+	return newsletter
+}
+
+func (w *whatsApp) ParseEventMessage(instance *Instance, message *events.Message) (Message, error) {
+	content := message.Message
 	base := Message{
 		InstanceID: instance.ID,
-		Body:       text,
 		MessageID:  message.Info.ID,
 		ChatJID:    message.Info.Chat.User,
 		SenderJID:  message.Info.Sender.User,
+		Chat:       message.Info.Chat,
+		Sender:     message.Info.Sender,
 		FromMe:     message.Info.MessageSource.IsFromMe,
 		Timestamp:  message.Info.Timestamp,
 	}
 
-	if media != nil && err == nil {
-		base.MediaType = &media.Type
-		base.Mimetype = &media.Mimetype
-		base.Media = &media.Data
+	// edits and revokes arrive as protocol messages keyed to the original
+	if protocol := content.GetProtocolMessage(); protocol != nil {
+		switch protocol.GetType() {
+		case waProto.ProtocolMessage_MESSAGE_EDIT:
+			content = protocol.GetEditedMessage()
+			base.MessageID = protocol.GetKey().GetID()
+			base.Edited = true
+		case waProto.ProtocolMessage_REVOKE:
+			base.MessageID = protocol.GetKey().GetID()
+			base.Revoked = true
+			return base, nil
+		}
+	}
+
+	base.Body = w.getTextMessage(content)
+	base.Caption = w.getCaption(content)
+	if base.Edited {
+		return base, nil
+	}
+
+	_, media := w.getMedia(content)
+	if media == nil {
 		return base, nil
 	}
 
+	// the media itself is downloaded later from the raw message
+	raw, err := proto.Marshal(content)
+	if err != nil {
+		return Message{}, err
+	}
+
+	base.MediaType = &media.Type
+	base.Mimetype = &media.Mimetype
+	base.Raw = raw
+	if len(media.Thumbnail) > 0 {
+		base.Thumbnail = &media.Thumbnail
+	}
 	return base, nil
 }
 
+func (w *whatsApp) DownloadMedia(instance *Instance, raw []byte) (*DownloadResponse, error) {
+	var message waProto.Message
+	if err := proto.Unmarshal(raw, &message); err != nil {
+		return nil, err
+	}
+
+	media, err := w.downloadMedia(instance, &message)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, errors.New("message has no media")
+	}
+	return media, nil
+}
+
+func (w *whatsApp) RequestMediaRetry(instance *Instance, raw []byte, info MediaRetryInfo) error {
+	downloadable, err := w.parseDownloadable(raw)
+	if err != nil {
+		return err
+	}
+
+	return instance.Client.SendMediaRetryReceipt(&types.MessageInfo{
+		ID: info.MessageID,
+		MessageSource: types.MessageSource{
+			Chat:     info.Chat,
+			Sender:   info.Sender,
+			IsFromMe: info.FromMe,
+			IsGroup:  info.Chat.Server == types.GroupServer,
+		},
+	}, downloadable.GetMediaKey())
+}
+
+// ApplyMediaRetry decrypts the phone's answer to a media retry receipt and
+// returns the raw message pointing to the re-uploaded media.
+func (w *whatsApp) ApplyMediaRetry(raw []byte, evt *events.MediaRetry) ([]byte, error) {
+	var message waProto.Message
+	if err := proto.Unmarshal(raw, &message); err != nil {
+		return nil, err
+	}
+
+	downloadable, _ := w.getMedia(&message)
+	if downloadable == nil {
+		return nil, errors.New("message has no media")
+	}
+
+	notification, err := whatsmeow.DecryptMediaRetryNotification(evt, downloadable.GetMediaKey())
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.GetResult() != waMmsRetry.MediaRetryNotification_SUCCESS {
+		return nil, fmt.Errorf("media retry failed with result %s", notification.GetResult())
+	}
+
+	directPath := proto.String(notification.GetDirectPath())
+	switch media := downloadable.(type) {
+	case *waProto.DocumentMessage:
+		media.DirectPath = directPath
+	case *waProto.AudioMessage:
+		media.DirectPath = directPath
+	case *waProto.ImageMessage:
+		media.DirectPath = directPath
+	case *waProto.StickerMessage:
+		media.DirectPath = directPath
+	case *waProto.VideoMessage:
+		media.DirectPath = directPath
+	}
+
+	return proto.Marshal(&message)
+}
+
+// IsMediaExpired reports whether a download failed because the media is no
+// longer on the WhatsApp CDN, which a media retry receipt can fix.
+func IsMediaExpired(err error) bool {
+	return errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith403) ||
+		errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith404) ||
+		errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith410)
+}
+
 func (w *whatsApp) createClient(deviceStore *store.Device) *whatsmeow.Client {
 	cfg := config.Load()
 
@@ -378,7 +1251,10 @@ func (w *whatsApp) createClient(deviceStore *store.Device) *whatsmeow.Client {
 		level = "ERROR"
 	}
 	log := waLog.Stdout("Client", level, true)
-	return whatsmeow.NewClient(deviceStore, log)
+	client := whatsmeow.NewClient(deviceStore, log)
+	// reconnection is supervised by the service, with backoff and reporting
+	client.EnableAutoReconnect = false
+	return client
 }
 
 func (w *whatsApp) uploadMedia(instance *Instance, media *dataurl.DataURL, mediaType MediaType) (*UploadResponse, error) {
@@ -390,6 +1266,8 @@ func (w *whatsApp) uploadMedia(instance *Instance, media *dataurl.DataURL, media
 		mType = whatsmeow.MediaAudio
 	case Document:
 		mType = whatsmeow.MediaDocument
+	case Video:
+		mType = whatsmeow.MediaVideo
 	default:
 		return nil, errors.New("unknown media type")
 	}
@@ -411,75 +1289,77 @@ func (w *whatsApp) uploadMedia(instance *Instance, media *dataurl.DataURL, media
 }
 
 func (w *whatsApp) downloadMedia(instance *Instance, message *waProto.Message) (*DownloadResponse, error) {
+	downloadable, media := w.getMedia(message)
+	if media == nil {
+		return nil, nil
+	}
+
+	data, err := instance.Client.Download(context.Background(), downloadable)
+	if err != nil {
+		return media, err
+	}
+
+	media.Data = data
+	return media, nil
+}
+
+func (w *whatsApp) parseDownloadable(raw []byte) (whatsmeow.DownloadableMessage, error) {
+	var message waProto.Message
+	if err := proto.Unmarshal(raw, &message); err != nil {
+		return nil, err
+	}
+
+	downloadable, _ := w.getMedia(&message)
+	if downloadable == nil {
+		return nil, errors.New("message has no media")
+	}
+	return downloadable, nil
+}
+
+func (w *whatsApp) getMedia(message *waProto.Message) (whatsmeow.DownloadableMessage, *DownloadResponse) {
 	document := message.GetDocumentMessage()
 	if document != nil {
-		data, err := instance.Client.Download(context.Background(), document)
-		if err != nil {
-			return &DownloadResponse{Type: Document}, err
+		return document, &DownloadResponse{
+			Type:      Document,
+			Mimetype:  document.GetMimetype(),
+			Thumbnail: document.GetJPEGThumbnail(),
 		}
-
-		return &DownloadResponse{
-			Data:     data,
-			Type:     Document,
-			Mimetype: document.GetMimetype(),
-		}, nil
 	}
 
 	audio := message.GetAudioMessage()
 	if audio != nil {
-		data, err := instance.Client.Download(context.Background(), audio)
-		if err != nil {
-			return &DownloadResponse{Type: Audio}, err
-		}
-
-		return &DownloadResponse{
-			Data:     data,
+		return audio, &DownloadResponse{
 			Type:     Audio,
 			Mimetype: audio.GetMimetype(),
-		}, nil
+		}
 	}
 
 	image := message.GetImageMessage()
 	if image != nil {
-		data, err := instance.Client.Download(context.Background(), image)
-		if err != nil {
-			return &DownloadResponse{Type: Image}, err
+		return image, &DownloadResponse{
+			Type:      Image,
+			Mimetype:  image.GetMimetype(),
+			Thumbnail: image.GetJPEGThumbnail(),
 		}
-
-		return &DownloadResponse{
-			Data:     data,
-			Type:     Image,
-			Mimetype: image.GetMimetype(),
-		}, nil
 	}
 
 	sticker := message.GetStickerMessage()
 	if sticker != nil {
-		data, err := instance.Client.Download(context.Background(), sticker)
-		if err != nil {
-			return &DownloadResponse{Type: Sticker}, err
+		return sticker, &DownloadResponse{
+			Type:      Sticker,
+			Mimetype:  sticker.GetMimetype(),
+			Thumbnail: sticker.GetPngThumbnail(),
 		}
-
-		return &DownloadResponse{
-			Data:     data,
-			Type:     Sticker,
-			Mimetype: sticker.GetMimetype(),
-		}, nil
 	}
 
-	// video := message.GetVideoMessage()
-	// if video != nil {
-	// 	data, err := instance.Client.Download(video)
-	// 	if err != nil {
-	// 		return &DownloadResponse{Type: Video}, err
-	// 	}
-
-	// 	return &DownloadResponse{
-	// 		Data:     data,
-	// 		Type:     Video,
-	// 		Mimetype: video.GetMimetype(),
-	// 	}, nil
-	// }
+	video := message.GetVideoMessage()
+	if video != nil {
+		return video, &DownloadResponse{
+			Type:      Video,
+			Mimetype:  video.GetMimetype(),
+			Thumbnail: video.GetJPEGThumbnail(),
+		}
+	}
 
 	return nil, nil
 }
@@ -487,11 +1367,24 @@ func (w *whatsApp) downloadMedia(instance *Instance, message *waProto.Message) (
 func (w *whatsApp) getTextMessage(message *waProto.Message) string {
 	extendedTextMessage := message.GetExtendedTextMessage()
 	if extendedTextMessage != nil {
-		return *extendedTextMessage.Text
+		return extendedTextMessage.GetText()
 	}
 	return message.GetConversation()
 }
 
+func (w *whatsApp) getCaption(message *waProto.Message) string {
+	if image := message.GetImageMessage(); image != nil {
+		return image.GetCaption()
+	}
+	if video := message.GetVideoMessage(); video != nil {
+		return video.GetCaption()
+	}
+	if document := message.GetDocumentMessage(); document != nil {
+		return document.GetCaption()
+	}
+	return ""
+}
+
 func (w *whatsApp) generateQrcode(instance *Instance, qrcodeHandler func(evt string, qrcode string, err error)) {
 	qrChan, err := instance.Client.GetQRChannel(context.Background())
 	if err != nil {
@@ -513,6 +1406,7 @@ func (w *whatsApp) generateQrcode(instance *Instance, qrcodeHandler func(evt str
 
 				switch evt.Event {
 				case "code":
+					instance.qrCodeOnce.Do(func() { close(instance.qrCodeReady) })
 					instance.QrCodeRateLimit -= 1
 					qrcodeHandler("code", evt.Code, nil)
 				default:
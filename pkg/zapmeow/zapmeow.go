@@ -53,3 +53,13 @@ func (a *ZapMeow) StoreInstance(instanceID string, instance *whatsapp.Instance)
 func (a *ZapMeow) DeleteInstance(instanceID string) {
 	a.Instances.Delete(instanceID)
 }
+
+// InstanceIDs lists the instances running on this node.
+func (a *ZapMeow) InstanceIDs() []string {
+	var ids []string
+	a.Instances.Range(func(key, value interface{}) bool {
+		ids = append(ids, key.(string))
+		return true
+	})
+	return ids
+}
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"time"
+	"zapmeow/api/service"
+	"zapmeow/pkg/cluster"
+	"zapmeow/pkg/logger"
+	"zapmeow/pkg/whatsapp"
+	"zapmeow/pkg/zapmeow"
+)
+
+type clusterWorker struct {
+	app             *zapmeow.ZapMeow
+	cluster         cluster.Cluster
+	accountService  service.AccountService
+	whatsAppService service.WhatsAppService
+}
+
+type ClusterWorker interface {
+	ProcessLeases()
+}
+
+func NewClusterWorker(
+	app *zapmeow.ZapMeow,
+	cluster cluster.Cluster,
+	accountService service.AccountService,
+	whatsAppService service.WhatsAppService,
+) *clusterWorker {
+	return &clusterWorker{
+		app:             app,
+		cluster:         cluster,
+		accountService:  accountService,
+		whatsAppService: whatsAppService,
+	}
+}
+
+// ProcessLeases keeps the node and its leases alive, and claims the
+// instances left behind by nodes that died, up to a fair share.
+func (c *clusterWorker) ProcessLeases() {
+	defer c.app.Wg.Done()
+	ticker := time.NewTicker(c.app.Config.ClusterLeaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := c.cluster.Heartbeat(); err != nil {
+			logger.Error("Error sending cluster heartbeat. ", err)
+		}
+
+		c.renewLeases()
+
+		if err := c.claimInstances(); err != nil {
+			logger.Error("Error claiming instances. ", err)
+		}
+
+		select {
+		case <-*c.app.StopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *clusterWorker) renewLeases() {
+	c.app.Instances.Range(func(key, value interface{}) bool {
+		instance := value.(*whatsapp.Instance)
+		renewed, err := c.cluster.Renew(instance.ID)
+		if err != nil {
+			logger.Error("Error renewing instance lease. ", err)
+			return true
+		}
+
+		// the lease expired and another node runs the instance now, two
+		// clients on the same session would keep kicking each other out
+		if !renewed {
+			logger.Info("Lost instance lease: ", instance.ID)
+			c.whatsAppService.UnloadInstance(instance.ID)
+		}
+		return true
+	})
+}
+
+func (c *clusterWorker) claimInstances() error {
+	nodes, err := c.cluster.Nodes()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := c.accountService.GetConnectedAccounts()
+	if err != nil {
+		return err
+	}
+
+	share := (len(accounts) + len(nodes) - 1) / max(len(nodes), 1)
+	local := 0
+	c.app.Instances.Range(func(key, value interface{}) bool {
+		local++
+		return true
+	})
+
+	for _, account := range accounts {
+		if local >= share {
+			return nil
+		}
+
+		if c.app.LoadInstance(account.InstanceID) != nil {
+			continue
+		}
+
+		owner, err := c.cluster.Owner(account.InstanceID)
+		if err != nil {
+			return err
+		}
+		if owner != nil {
+			continue
+		}
+
+		logger.Info("Claiming instance: ", account.InstanceID)
+		_, err = c.whatsAppService.GetInstance(account.InstanceID)
+		if err != nil {
+			logger.Error("Error getting instance. ", err)
+			continue
+		}
+		local++
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"errors"
 	"sort"
 	"time"
 	"zapmeow/api/helper"
@@ -21,7 +22,10 @@ type historySyncWorker struct {
 	app             *zapmeow.ZapMeow
 	messageService  service.MessageService
 	accountService  service.AccountService
+	chatService     service.ChatService
+	contactService  service.ContactService
 	whatsAppService service.WhatsAppService
+	settingsService service.SettingsService
 }
 
 type HistorySyncWorker interface {
@@ -32,12 +36,18 @@ func NewHistorySyncWorker(
 	app *zapmeow.ZapMeow,
 	messageService service.MessageService,
 	accountService service.AccountService,
+	chatService service.ChatService,
+	contactService service.ContactService,
 	whatsAppService service.WhatsAppService,
+	settingsService service.SettingsService,
 ) *historySyncWorker {
 	return &historySyncWorker{
 		messageService:  messageService,
 		accountService:  accountService,
+		chatService:     chatService,
+		contactService:  contactService,
 		whatsAppService: whatsAppService,
+		settingsService: settingsService,
 		app:             app,
 	}
 }
@@ -50,25 +60,38 @@ func (q *historySyncWorker) ProcessQueue() {
 		case <-*q.app.StopCh:
 			return
 		default:
-			if err := q.processHistorySync(queue); err != nil {
-				logger.Error("Error processing history sync. ", err)
-			}
+			q.processQueues(queue)
 		}
 
 		time.Sleep(3 * time.Second)
 	}
 }
 
-func (q *historySyncWorker) processHistorySync(queue queue.HistorySyncQueue) error {
-	data, err := queue.Dequeue()
-	if err != nil {
-		return err
-	}
+// processQueues takes one history sync from the queue of every instance
+// running on this node.
+func (q *historySyncWorker) processQueues(historySyncQueue queue.HistorySyncQueue) {
+	for _, instanceID := range q.app.InstanceIDs() {
+		data, err := historySyncQueue.Dequeue(instanceID)
+		if err != nil || data == nil {
+			continue
+		}
 
-	if data == nil {
-		return nil
+		err = q.processHistorySync(data)
+		if errors.Is(err, service.ErrInstanceOnOtherNode) {
+			// the instance is moving to another node, which takes the
+			// history from the same queue
+			if err := historySyncQueue.Enqueue(*data); err != nil {
+				logger.Error("Failed to add history sync to queue. ", err)
+			}
+			continue
+		}
+		if err != nil {
+			logger.Error("Error processing history sync. ", err)
+		}
 	}
+}
 
+func (q *historySyncWorker) processHistorySync(data *queue.HistorySyncQueueData) error {
 	historySync, err := q.parseHistorySync(data.History)
 	if err != nil {
 		return err
@@ -84,11 +107,8 @@ func (q *historySyncWorker) processHistorySync(queue queue.HistorySyncQueue) err
 		return err
 	}
 
+	// messages are upserted, so repeated syncs merge with what is stored
 	if !account.WasSynced {
-		if err := q.accountService.DeleteAccountMessages(account.InstanceID); err != nil {
-			return err
-		}
-
 		if err := q.accountService.UpdateAccount(account.InstanceID, map[string]interface{}{
 			"WasSynced": true,
 		}); err != nil {
@@ -96,6 +116,10 @@ func (q *historySyncWorker) processHistorySync(queue queue.HistorySyncQueue) err
 		}
 	}
 
+	if err := q.processPushNames(historySync, account); err != nil {
+		return err
+	}
+
 	messages, err := q.processMessages(historySync, account, instance)
 	if err != nil {
 		return err
@@ -105,6 +129,22 @@ func (q *historySyncWorker) processHistorySync(queue queue.HistorySyncQueue) err
 		return err
 	}
 
+	mediaQueue := queue.NewMediaQueue(q.app)
+	for _, message := range messages {
+		// the stored status, media that expired or failed stays that way
+		if message.MediaStatus != model.MediaStatusPending && message.MediaStatus != model.MediaStatusRetry {
+			continue
+		}
+
+		err := mediaQueue.Enqueue(queue.MediaQueueData{
+			InstanceID: message.InstanceID,
+			ID:         message.ID,
+		})
+		if err != nil {
+			logger.Error("Failed to add media to queue. ", err)
+		}
+	}
+
 	return nil
 }
 
@@ -116,18 +156,44 @@ func (q *historySyncWorker) parseHistorySync(history []byte) (*waProto.HistorySy
 	return &data, nil
 }
 
+func (q *historySyncWorker) processPushNames(evt *waProto.HistorySync, account *model.Account) error {
+	pushNames := map[types.JID]string{}
+	for _, pushName := range evt.GetPushnames() {
+		jid, err := types.ParseJID(pushName.GetID())
+		if err != nil {
+			continue
+		}
+		pushNames[jid] = pushName.GetPushname()
+	}
+	return q.contactService.SavePushNames(account.InstanceID, pushNames)
+}
+
 func (q *historySyncWorker) processMessages(evt *waProto.HistorySync, account *model.Account, instance *whatsapp.Instance) ([]model.Message, error) {
 	var messages []model.Message
 
+	settings, err := q.settingsService.GetSettings(account.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, conv := range evt.GetConversations() {
 		chatJID, _ := types.ParseJID(conv.GetId())
+		// statuses are short lived and never stored as chat history, and
+		// channel posts are fetched from WhatsApp
+		if chatJID == types.StatusBroadcastJID || chatJID.Server == types.NewsletterServer {
+			continue
+		}
+
+		if err := q.chatService.UpsertChat(q.makeChat(account, conv, chatJID)); err != nil {
+			return nil, err
+		}
 
 		count, err := q.messageService.CountChatMessages(account.InstanceID, chatJID.User)
 		if err != nil {
 			return nil, err
 		}
 
-		if count > int64(q.app.Config.MaxMessageSync) {
+		if count > int64(settings.MaxMessageSync) {
 			continue
 		}
 
@@ -145,20 +211,16 @@ func (q *historySyncWorker) processMessages(evt *waProto.HistorySync, account *m
 			return eventsMessage[i].Info.Timestamp.After(eventsMessage[j].Info.Timestamp)
 		})
 
-		maxMessages := helper.Min(q.app.Config.MaxMessageSync, len(eventsMessage))
+		maxMessages := helper.Min(settings.MaxMessageSync, len(eventsMessage))
 		slice := eventsMessage[:maxMessages]
 
 		for _, evtMessage := range slice {
 			parsedEvtMesage, err := q.whatsAppService.ParseEventMessage(instance, evtMessage)
-			if err != nil {
+			if err != nil || parsedEvtMesage.Revoked {
 				continue
 			}
 
-			message, err := q.makeMessage(instance, parsedEvtMesage)
-			if err != nil {
-				continue
-			}
-			messages = append(messages, *message)
+			messages = append(messages, q.makeMessage(instance, parsedEvtMesage))
 		}
 	}
 
@@ -177,32 +239,73 @@ func (q *historySyncWorker) processConversation(conv *waProto.Conversation, chat
 	return eventsMessage, nil
 }
 
-func (q *historySyncWorker) makeMessage(instance *whatsapp.Instance, parsedMessage whatsapp.Message) (*model.Message, error) {
-	message := model.Message{
-		SenderJID:  parsedMessage.SenderJID,
-		ChatJID:    parsedMessage.ChatJID,
-		InstanceID: parsedMessage.InstanceID,
-		MessageID:  parsedMessage.MessageID,
-		Timestamp:  parsedMessage.Timestamp,
-		Body:       parsedMessage.Body,
-		FromMe:     parsedMessage.FromMe,
+func (q *historySyncWorker) makeChat(account *model.Account, conv *waProto.Conversation, chatJID types.JID) *model.Chat {
+	chat := model.Chat{
+		InstanceID:   account.InstanceID,
+		ChatJID:      chatJID.User,
+		RemoteJID:    chatJID.String(),
+		Name:         conv.GetName(),
+		UnreadCount:  int(conv.GetUnreadCount()),
+		MarkedUnread: conv.GetMarkedAsUnread(),
+		Archived:     conv.GetArchived(),
+		Pinned:       conv.GetPinned() != 0,
 	}
 
-	if parsedMessage.MediaType != nil {
-		path, err := helper.SaveMedia(
-			instance.ID,
-			parsedMessage.MessageID,
-			*parsedMessage.Media,
-			*parsedMessage.Mimetype,
-		)
+	// chats muted forever carry -1, and some clients send milliseconds
+	if muteEndTime := int64(conv.GetMuteEndTime()); muteEndTime < 0 {
+		mutedUntil := model.MutedForever
+		chat.MutedUntil = &mutedUntil
+	} else if muteEndTime > 1e12 {
+		mutedUntil := time.UnixMilli(muteEndTime)
+		chat.MutedUntil = &mutedUntil
+	} else if muteEndTime > 0 {
+		mutedUntil := time.Unix(muteEndTime, 0)
+		chat.MutedUntil = &mutedUntil
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	lastMessageTime := conv.GetLastMsgTimestamp()
+	if lastMessageTime == 0 {
+		lastMessageTime = conv.GetConversationTimestamp()
+	}
+	if lastMessageTime != 0 {
+		lastMessageAt := time.Unix(int64(lastMessageTime), 0)
+		chat.LastMessageAt = &lastMessageAt
+	}
+	return &chat
+}
 
+func (q *historySyncWorker) makeMessage(instance *whatsapp.Instance, parsedMessage whatsapp.Message) model.Message {
+	message := model.Message{
+		SenderJID:      parsedMessage.SenderJID,
+		ChatJID:        parsedMessage.ChatJID,
+		RemoteJID:      parsedMessage.Chat.String(),
+		ParticipantJID: parsedMessage.Sender.String(),
+		InstanceID:     parsedMessage.InstanceID,
+		MessageID:      parsedMessage.MessageID,
+		Timestamp:      parsedMessage.Timestamp,
+		Body:           parsedMessage.Body,
+		Caption:        parsedMessage.Caption,
+		FromMe:         parsedMessage.FromMe,
+	}
+
+	if parsedMessage.MediaType != nil {
 		message.MediaType = parsedMessage.MediaType.String()
-		message.MediaPath = path
+		message.MediaStatus = model.MediaStatusPending
+		message.RawMessage = parsedMessage.Raw
+
+		if parsedMessage.Thumbnail != nil {
+			thumbnailPath, err := helper.SaveThumbnail(
+				instance.ID,
+				parsedMessage.MessageID,
+				*parsedMessage.Thumbnail,
+			)
+			// the message is kept without a thumbnail, as live messages are
+			if err != nil {
+				logger.Error("Failed to save thumbnail. ", err)
+			}
+			message.ThumbnailPath = thumbnailPath
+		}
 	}
 
-	return &message, nil
+	return message
 }
@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"os"
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/service"
+	"zapmeow/pkg/logger"
+	"zapmeow/pkg/zapmeow"
+)
+
+const mediaRetentionInterval = time.Hour
+
+type mediaRetentionWorker struct {
+	app            *zapmeow.ZapMeow
+	messageService service.MessageService
+	accountService service.AccountService
+	mediaService   service.MediaService
+}
+
+type MediaRetentionWorker interface {
+	ProcessRetention()
+}
+
+func NewMediaRetentionWorker(
+	app *zapmeow.ZapMeow,
+	messageService service.MessageService,
+	accountService service.AccountService,
+	mediaService service.MediaService,
+) *mediaRetentionWorker {
+	return &mediaRetentionWorker{
+		app:            app,
+		messageService: messageService,
+		accountService: accountService,
+		mediaService:   mediaService,
+	}
+}
+
+func (m *mediaRetentionWorker) ProcessRetention() {
+	defer m.app.Wg.Done()
+	ticker := time.NewTicker(mediaRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.enforceRetention(); err != nil {
+			logger.Error("Error enforcing media retention. ", err)
+		}
+
+		select {
+		case <-*m.app.StopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *mediaRetentionWorker) enforceRetention() error {
+	instanceIDs, err := m.getInstanceIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, instanceID := range instanceIDs {
+		if err := m.enforceAccountRetention(instanceID); err != nil {
+			logger.ErrorWithFields("Error enforcing media retention for instance. ", logger.Fields{
+				"instanceId": instanceID,
+				"error":      err,
+			})
+		}
+	}
+	return nil
+}
+
+// getInstanceIDs returns the instances whose media this node purges. In
+// cluster mode every node runs retention, so each one only takes the
+// instances it holds.
+func (m *mediaRetentionWorker) getInstanceIDs() ([]string, error) {
+	if m.app.Config.ClusterMode {
+		return m.app.InstanceIDs(), nil
+	}
+
+	accounts, err := m.accountService.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		instanceIDs = append(instanceIDs, account.InstanceID)
+	}
+	return instanceIDs, nil
+}
+
+func (m *mediaRetentionWorker) enforceAccountRetention(instanceID string) error {
+	messages, err := m.messageService.GetMediaMessages(instanceID)
+	if err != nil {
+		return err
+	}
+
+	instancePolicy, err := m.mediaService.GetRetentionPolicy(instanceID, "")
+	if err != nil {
+		return err
+	}
+
+	policies := map[string]model.MediaRetentionPolicy{}
+	var remaining []model.Message
+	var totalBytes int64
+	now := time.Now()
+
+	for _, message := range *messages {
+		policy, ok := policies[message.MediaType]
+		if !ok {
+			policy, err = m.mediaService.GetRetentionPolicy(instanceID, message.MediaType)
+			if err != nil {
+				return err
+			}
+			policies[message.MediaType] = policy
+		}
+
+		if policy.MaxAgeDays > 0 && message.Timestamp.Before(now.AddDate(0, 0, -policy.MaxAgeDays)) {
+			if err := m.mediaService.PurgeMedia(message, policy.KeepThumbnail, "max-age"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		remaining = append(remaining, message)
+		totalBytes += fileSize(message.MediaPath)
+	}
+
+	if instancePolicy.MaxBytes <= 0 {
+		return nil
+	}
+
+	// messages are ordered by timestamp, so the oldest media goes first
+	for _, message := range remaining {
+		if totalBytes <= instancePolicy.MaxBytes {
+			break
+		}
+
+		size := fileSize(message.MediaPath)
+		policy := policies[message.MediaType]
+		if err := m.mediaService.PurgeMedia(message, policy.KeepThumbnail, "max-bytes"); err != nil {
+			return err
+		}
+		totalBytes -= size
+	}
+
+	return nil
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"slices"
+	"testing"
+	"zapmeow/api/model"
+	"zapmeow/api/service"
+)
+
+type allAccountsService struct {
+	service.AccountService
+}
+
+func (s *allAccountsService) GetAccounts() ([]model.Account, error) {
+	return []model.Account{{InstanceID: "a"}, {InstanceID: "b"}}, nil
+}
+
+func TestRetentionTakesTheInstancesOfThisNodeInClusterMode(t *testing.T) {
+	app := newNode(&memoryQueue{}, "a")
+	worker := NewMediaRetentionWorker(app, nil, &allAccountsService{}, nil)
+
+	instanceIDs, err := worker.getInstanceIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(instanceIDs, []string{"a", "b"}) {
+		t.Fatalf("single node retention took %v, want every account", instanceIDs)
+	}
+
+	app.Config.ClusterMode = true
+	instanceIDs, err = worker.getInstanceIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(instanceIDs, []string{"a"}) {
+		t.Fatalf("cluster retention took %v, want only the instance of this node", instanceIDs)
+	}
+}
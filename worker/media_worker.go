@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"zapmeow/api/model"
+	"zapmeow/api/queue"
+	"zapmeow/api/service"
+	"zapmeow/pkg/logger"
+	"zapmeow/pkg/zapmeow"
+)
+
+type mediaWorker struct {
+	app             *zapmeow.ZapMeow
+	messageService  service.MessageService
+	statusService   service.StatusService
+	whatsAppService service.WhatsAppService
+}
+
+type MediaWorker interface {
+	ProcessQueue()
+}
+
+func NewMediaWorker(
+	app *zapmeow.ZapMeow,
+	messageService service.MessageService,
+	statusService service.StatusService,
+	whatsAppService service.WhatsAppService,
+) *mediaWorker {
+	return &mediaWorker{
+		app:             app,
+		messageService:  messageService,
+		statusService:   statusService,
+		whatsAppService: whatsAppService,
+	}
+}
+
+// ProcessQueue downloads pending media with a fixed pool of workers, so a
+// slow CDN never blocks the whatsmeow event handlers.
+func (m *mediaWorker) ProcessQueue() {
+	defer m.app.Wg.Done()
+
+	var wg sync.WaitGroup
+	for i := 0; i < m.app.Config.MediaWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.work()
+		}()
+	}
+	wg.Wait()
+}
+
+func (m *mediaWorker) work() {
+	mediaQueue := queue.NewMediaQueue(m.app)
+	for {
+		select {
+		case <-*m.app.StopCh:
+			return
+		default:
+		}
+
+		if !m.processQueues(mediaQueue) {
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+// processQueues takes one job from the queue of every instance running on
+// this node, and tells whether there was any.
+func (m *mediaWorker) processQueues(mediaQueue queue.MediaQueue) bool {
+	processed := false
+	for _, instanceID := range m.app.InstanceIDs() {
+		data, err := mediaQueue.Dequeue(instanceID)
+		if err != nil || data == nil {
+			continue
+		}
+
+		err = m.processMedia(data)
+		if errors.Is(err, service.ErrInstanceOnOtherNode) {
+			// the instance is moving to another node, which takes the job
+			// from the same queue
+			if err := mediaQueue.Enqueue(*data); err != nil {
+				logger.Error("Failed to add media to queue. ", err)
+			}
+			continue
+		}
+
+		processed = true
+		if err != nil {
+			logger.Error("Error processing media. ", err)
+		}
+	}
+	return processed
+}
+
+func (m *mediaWorker) processMedia(data *queue.MediaQueueData) error {
+	if data.Status {
+		return m.processStatusMedia(data)
+	}
+
+	message, err := m.messageService.GetMessage(data.ID)
+	if err != nil {
+		return err
+	}
+
+	// media already downloaded, given up on or expired by the retention
+	// policy is not downloaded again
+	if message == nil || (message.MediaStatus != model.MediaStatusPending && message.MediaStatus != model.MediaStatusRetry) {
+		return nil
+	}
+
+	instance, err := m.whatsAppService.GetInstance(data.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = m.whatsAppService.DownloadMedia(instance, message)
+		if err == nil || errors.Is(err, service.ErrMediaRetryRequested) {
+			return nil
+		}
+
+		if attempt >= m.app.Config.MediaMaxRetries {
+			break
+		}
+		time.Sleep(time.Duration(1<<attempt) * time.Second)
+	}
+
+	logger.ErrorWithFields("Failed to download media. ", logger.Fields{
+		"instanceId": data.InstanceID,
+		"messageId":  message.MessageID,
+		"error":      err,
+	})
+
+	return m.whatsAppService.FailMedia(data.InstanceID, message)
+}
+
+func (m *mediaWorker) processStatusMedia(data *queue.MediaQueueData) error {
+	status, err := m.statusService.GetStatus(data.ID)
+	if err != nil {
+		return err
+	}
+
+	if status == nil || status.Revoked || status.MediaStatus == model.MediaStatusReady {
+		return nil
+	}
+
+	instance, err := m.whatsAppService.GetInstance(data.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = m.whatsAppService.DownloadStatusMedia(instance, status)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= m.app.Config.MediaMaxRetries {
+			break
+		}
+		time.Sleep(time.Duration(1<<attempt) * time.Second)
+	}
+
+	logger.ErrorWithFields("Failed to download status media. ", logger.Fields{
+		"instanceId": data.InstanceID,
+		"statusId":   status.StatusID,
+		"error":      err,
+	})
+
+	return m.whatsAppService.FailStatusMedia(data.InstanceID, status)
+}
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"sync"
+	"testing"
+	"zapmeow/api/model"
+	"zapmeow/api/queue"
+	"zapmeow/api/service"
+	"zapmeow/config"
+	"zapmeow/pkg/whatsapp"
+	"zapmeow/pkg/zapmeow"
+)
+
+type memoryQueue struct {
+	mutex sync.Mutex
+	items map[string][][]byte
+}
+
+func (q *memoryQueue) Enqueue(queueName string, data []byte) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.items[queueName] = append([][]byte{data}, q.items[queueName]...)
+	return nil
+}
+
+func (q *memoryQueue) Dequeue(queueName string) ([]byte, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	items := q.items[queueName]
+	if len(items) == 0 {
+		return nil, nil
+	}
+	q.items[queueName] = items[1:]
+	return items[0], nil
+}
+
+func (q *memoryQueue) Close() error {
+	return nil
+}
+
+type otherNodeWhatsAppService struct {
+	service.WhatsAppService
+}
+
+func (s *otherNodeWhatsAppService) GetInstance(instanceID string) (*whatsapp.Instance, error) {
+	return nil, service.ErrInstanceOnOtherNode
+}
+
+type pendingMessageService struct {
+	service.MessageService
+}
+
+func (s *pendingMessageService) GetMessage(id uint) (*model.Message, error) {
+	return &model.Message{MediaStatus: model.MediaStatusPending}, nil
+}
+
+func newNode(q *memoryQueue, instanceIDs ...string) *zapmeow.ZapMeow {
+	app := zapmeow.NewZapMeow(
+		nil,
+		q,
+		config.Config{MediaQueueName: "media"},
+		&sync.Map{},
+		&sync.WaitGroup{},
+		&sync.Mutex{},
+		nil,
+	)
+	for _, id := range instanceIDs {
+		app.StoreInstance(id, &whatsapp.Instance{ID: id})
+	}
+	return app
+}
+
+func TestMediaJobIsLeftForTheOwnerNode(t *testing.T) {
+	q := &memoryQueue{items: map[string][][]byte{}}
+	owner := newNode(q, "a")
+	other := newNode(q, "b")
+
+	err := queue.NewMediaQueue(owner).Enqueue(queue.MediaQueueData{InstanceID: "a", ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worker := NewMediaWorker(other, &pendingMessageService{}, nil, &otherNodeWhatsAppService{})
+	if worker.processQueues(queue.NewMediaQueue(other)) {
+		t.Fatal("node b took a job of instance a")
+	}
+
+	data, err := queue.NewMediaQueue(owner).Dequeue("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data == nil || data.ID != 1 {
+		t.Fatalf("job was lost, got %+v", data)
+	}
+}
+
+func TestMediaJobIsRequeuedWhenTheInstanceMoved(t *testing.T) {
+	q := &memoryQueue{items: map[string][][]byte{}}
+	// the instance is still loaded, but its lease went to another node
+	node := newNode(q, "a")
+
+	err := queue.NewMediaQueue(node).Enqueue(queue.MediaQueueData{InstanceID: "a", ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worker := NewMediaWorker(node, &pendingMessageService{}, nil, &otherNodeWhatsAppService{})
+	if worker.processQueues(queue.NewMediaQueue(node)) {
+		t.Fatal("job was processed on a node that does not own the instance")
+	}
+
+	data, err := queue.NewMediaQueue(node).Dequeue("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data == nil || data.ID != 1 {
+		t.Fatalf("job was lost, got %+v", data)
+	}
+}